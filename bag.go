@@ -0,0 +1,131 @@
+package grab
+
+import (
+	"context"
+	"sync"
+)
+
+// Bag is a request-scoped collection of values which is registered on a context once,
+// and can then be written to and read from with typed keys without deriving a new context per value.
+// It is safe for concurrent use.
+//
+// Example:
+// var pagesFetched = NewBagKey[int]("pages_fetched")
+//
+// ctx = WithBag(ctx)
+// pagesFetched.Set(ctx, 3)
+// n, ok := pagesFetched.Get(ctx) // n will be 3 and ok will be true
+//
+// Note: This type is useful for accumulating diagnostics (such as page counts or retry counts)
+// through a pipeline of grab helpers, and reporting them once at the end of a request.
+type Bag struct {
+	mu     sync.RWMutex
+	values map[any]bagValue
+}
+
+type bagValue struct {
+	name  string
+	value any
+}
+
+type bagContextKey struct{}
+
+// WithBag returns a copy of the parent context with a new, empty Bag registered on it.
+func WithBag(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bagContextKey{}, &Bag{values: make(map[any]bagValue)})
+}
+
+// BagFromContext returns the Bag registered on the context, or nil if there is none.
+func BagFromContext(ctx context.Context) *Bag {
+	b, _ := ctx.Value(bagContextKey{}).(*Bag)
+	return b
+}
+
+// Values returns a copy of all values in the Bag, keyed by the name of their BagKey.
+func (b *Bag) Values() map[string]any {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make(map[string]any, len(b.values))
+	for _, v := range b.values {
+		out[v.name] = v.value
+	}
+	return out
+}
+
+// BagKey is a typed key for values stored in a Bag.
+// Keys are compared by identity, so two keys created with the same name refer to different values.
+type BagKey[T any] struct {
+	name string
+}
+
+// NewBagKey creates a new typed key for values stored in a Bag.
+//
+// Parameters:
+//   - name: A descriptive name for the key, used when listing the values in a Bag.
+//
+// Returns:
+//   - *BagKey[T]: A new key for values of type 'T'.
+func NewBagKey[T any](name string) *BagKey[T] {
+	return &BagKey[T]{name: name}
+}
+
+// Name returns the descriptive name of the key.
+func (k *BagKey[T]) Name() string {
+	return k.name
+}
+
+// Set stores a value in the Bag registered on the context.
+// It returns false if there is no Bag registered on the context.
+func (k *BagKey[T]) Set(ctx context.Context, value T) bool {
+	b := BagFromContext(ctx)
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values[k] = bagValue{name: k.name, value: value}
+	return true
+}
+
+// Get returns the value stored in the Bag registered on the context.
+// It returns the zero value of type 'T' and false if there is no Bag registered on the context
+// or if no value has been set for the key.
+func (k *BagKey[T]) Get(ctx context.Context) (T, bool) {
+	b := BagFromContext(ctx)
+	if b == nil {
+		var zero T
+		return zero, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	v, ok := b.values[k]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return v.value.(T), true
+}
+
+// Update atomically replaces the value stored in the Bag registered on the context with the result of 'fn',
+// which receives the current value (or the zero value of type 'T' if none has been set).
+// It returns false if there is no Bag registered on the context.
+//
+// Example:
+// pagesFetched.Update(ctx, func(n int) int { return n + 1 })
+func (k *BagKey[T]) Update(ctx context.Context, fn func(T) T) bool {
+	b := BagFromContext(ctx)
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var current T
+	if v, ok := b.values[k]; ok {
+		current = v.value.(T)
+	}
+	b.values[k] = bagValue{name: k.name, value: fn(current)}
+	return true
+}
@@ -0,0 +1,42 @@
+package grab_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBag(t *testing.T) {
+	pages := grab.NewBagKey[int]("pages")
+	source := grab.NewBagKey[string]("source")
+
+	t.Run("no bag registered", func(t *testing.T) {
+		ctx := context.Background()
+		assert.False(t, pages.Set(ctx, 1))
+		got, ok := pages.Get(ctx)
+		assert.False(t, ok)
+		assert.Equal(t, 0, got)
+		assert.Nil(t, grab.BagFromContext(ctx).Values())
+	})
+
+	t.Run("set and get", func(t *testing.T) {
+		ctx := grab.WithBag(context.Background())
+		assert.True(t, pages.Set(ctx, 2))
+		assert.True(t, source.Set(ctx, "aws"))
+		assert.True(t, pages.Update(ctx, func(n int) int { return n + 1 }))
+
+		got, ok := pages.Get(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, 3, got)
+		assert.Equal(t, map[string]any{"pages": 3, "source": "aws"}, grab.BagFromContext(ctx).Values())
+	})
+
+	t.Run("unset key", func(t *testing.T) {
+		ctx := grab.WithBag(context.Background())
+		got, ok := source.Get(ctx)
+		assert.False(t, ok)
+		assert.Equal(t, "", got)
+	})
+}
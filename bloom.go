@@ -0,0 +1,105 @@
+package grab
+
+import (
+	"hash/maphash"
+	"math"
+	"sync/atomic"
+)
+
+// Bloom is a Bloom filter: a compact, approximate set which can report false positives,
+// but never false negatives.
+// It is safe for concurrent use.
+//
+// Hashes are seeded randomly per filter, so a Bloom is only meaningful within the process that created it.
+//
+// Example:
+// seen := NewBloom[string](10_000_000, 0.001)
+//
+//	for _, r := range resources {
+//	    if seen.MayContain(r.ARN) {
+//	        continue // probably a duplicate
+//	    }
+//	    seen.Add(r.ARN)
+//	}
+//
+// Note: This type is useful for deduplicating very large listings, where holding the exact set of keys
+// in memory would be too expensive and a small rate of false positives is acceptable.
+type Bloom[T comparable] struct {
+	bits   []atomic.Uint64
+	m      uint64 // number of bits
+	k      uint64 // number of hash functions
+	seed1  maphash.Seed
+	seed2  maphash.Seed
+	approx atomic.Int64
+}
+
+// NewBloom creates a Bloom filter sized to hold the expected number of items with the given false positive rate.
+//
+// Parameters:
+//   - expectedItems: The number of distinct items expected to be added. Values less than 1 are treated as 1.
+//   - falsePositiveRate: The desired probability that MayContain returns true for an item which was never added,
+//     once 'expectedItems' items have been added. It must be between 0 and 1 exclusive; other values are treated as 0.01.
+//
+// Returns:
+//   - *Bloom[T]: An empty Bloom filter.
+func NewBloom[T comparable](expectedItems int, falsePositiveRate float64) *Bloom[T] {
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	words := (uint64(m) + 63) / 64
+	return &Bloom[T]{
+		bits:  make([]atomic.Uint64, words),
+		m:     words * 64,
+		k:     uint64(k),
+		seed1: maphash.MakeSeed(),
+		seed2: maphash.MakeSeed(),
+	}
+}
+
+// Add adds an item to the filter.
+func (b *Bloom[T]) Add(item T) {
+	h1, h2 := b.hashes(item)
+	added := false
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		mask := uint64(1) << (bit % 64)
+		if b.bits[bit/64].Or(mask)&mask == 0 {
+			added = true
+		}
+	}
+	if added {
+		b.approx.Add(1)
+	}
+}
+
+// MayContain reports whether the item may have been added to the filter.
+// A false result means the item was definitely not added.
+func (b *Bloom[T]) MayContain(item T) bool {
+	h1, h2 := b.hashes(item)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64].Load()&(uint64(1)<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ApproximateLen returns an estimate of the number of distinct items added to the filter.
+// It undercounts items which collided entirely with previously added items.
+func (b *Bloom[T]) ApproximateLen() int {
+	return int(b.approx.Load())
+}
+
+// hashes returns two independent hashes of the item for double hashing.
+// The second hash is forced to be odd so that it is never zero.
+func (b *Bloom[T]) hashes(item T) (uint64, uint64) {
+	return maphash.Comparable(b.seed1, item), maphash.Comparable(b.seed2, item) | 1
+}
@@ -0,0 +1,38 @@
+package grab_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloom(t *testing.T) {
+	const n = 10000
+	b := grab.NewBloom[string](n, 0.01)
+
+	for i := 0; i < n; i++ {
+		b.Add(fmt.Sprintf("item-%d", i))
+	}
+
+	for i := 0; i < n; i++ {
+		assert.True(t, b.MayContain(fmt.Sprintf("item-%d", i)), "no false negatives")
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if b.MayContain(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/n, 0.03)
+	assert.InDelta(t, n, b.ApproximateLen(), n*0.02)
+}
+
+func TestBloomInvalidArguments(t *testing.T) {
+	b := grab.NewBloom[int](0, 2)
+	assert.False(t, b.MayContain(1))
+	b.Add(1)
+	assert.True(t, b.MayContain(1))
+}
@@ -0,0 +1,152 @@
+package grab
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and creates timers. Time-dependent helpers in this package accept a Clock
+// through the WithClock option, so they can be tested with a FakeClock instead of sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel which receives the current time once the duration has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTicker returns a Ticker which delivers ticks at intervals of the duration.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks at intervals, like a time.Ticker.
+type Ticker interface {
+	// C returns the channel on which ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker. No more ticks are delivered after Stop returns.
+	Stop()
+}
+
+// RealClock returns a Clock backed by the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// FakeClock is a Clock whose time only moves when Advance or Set is called.
+// Timers and tickers created from it fire when the fake time passes their deadline.
+// It is safe for concurrent use.
+//
+// Example:
+// clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+// idem := NewIdempotent[string, int](time.Minute, WithClock(clock))
+// clock.Advance(2 * time.Minute) // results stored by idem have now expired
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	at     time.Time
+	period time.Duration // zero for one-shot timers
+	c      chan time.Time
+}
+
+// NewFakeClock creates a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel which receives the fake time once it has been advanced by at least the duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- c.now
+		return w.c
+	}
+	c.waiters = append(c.waiters, w)
+	return w.c
+}
+
+// NewTicker returns a Ticker which ticks each time the fake time passes a multiple of the duration.
+// As with time.Ticker, ticks are dropped if the receiver falls behind.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("grab: non-positive interval for FakeClock.NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{at: c.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	c.waiters = append(c.waiters, w)
+	return &fakeTicker{clock: c, w: w}
+}
+
+// Advance moves the fake time forward by the duration, firing any timers and tickers which become due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set moves the fake time to the given time, firing any timers and tickers which become due.
+// Setting a time earlier than the current fake time does not fire anything.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(t)
+}
+
+func (c *FakeClock) setLocked(t time.Time) {
+	c.now = t
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(t) {
+			remaining = append(remaining, w)
+			continue
+		}
+		select {
+		case w.c <- t:
+		default:
+		}
+		if w.period > 0 {
+			for !w.at.After(t) {
+				w.at = w.at.Add(w.period)
+			}
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+}
+
+func (c *FakeClock) remove(w *fakeWaiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waiters = Filter(c.waiters, func(existing *fakeWaiter) bool { return existing != w })
+}
+
+type fakeTicker struct {
+	clock *FakeClock
+	w     *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.c }
+func (t *fakeTicker) Stop()               { t.clock.remove(t.w) }
@@ -0,0 +1,61 @@
+package grab_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClockAfter(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	c := clock.After(time.Minute)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-c:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-c:
+		assert.Equal(t, epoch.Add(time.Minute), got)
+	default:
+		t.Fatal("timer did not fire")
+	}
+	assert.Equal(t, epoch.Add(time.Minute), clock.Now())
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, epoch.Add(time.Second), <-ticker.C())
+
+	// ticks are dropped when the receiver falls behind
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+	assert.Equal(t, epoch.Add(2*time.Second), <-ticker.C())
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker fired")
+	default:
+	}
+}
+
+func TestRealClock(t *testing.T) {
+	clock := grab.RealClock()
+	before := time.Now()
+	<-clock.After(time.Millisecond)
+	assert.WithinDuration(t, time.Now(), clock.Now(), time.Second)
+	assert.True(t, clock.Now().After(before))
+}
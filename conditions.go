@@ -0,0 +1,101 @@
+package grab
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Conditions collects the filters set on a request, skipping fields which were left at their zero value,
+// and renders them as a map or a joined string, such as a query string or a filter expression.
+// Conditions are kept in the order they were added. The zero value is an empty set of conditions.
+//
+// Example:
+//
+//	var where Conditions
+//	where.Add("status", req.Status).
+//	    Add("owner", req.Owner).
+//	    Add("account_id", req.AccountID)
+//
+//	filter := where.Join(" = ", " AND ") // status = active AND owner = alice, if AccountID is unset
+type Conditions struct {
+	fields []string
+	values map[string]any
+}
+
+// Add adds a condition for the field, unless 'value' is nil, empty or the zero value for its type.
+// A non-nil pointer is dereferenced, so optional *T fields can be added directly.
+// Adding a field which is already present replaces its value but keeps its position.
+func (c *Conditions) Add(field string, value any) *Conditions {
+	if value, ok := conditionValue(value); ok {
+		c.set(field, value)
+	}
+	return c
+}
+
+// AddIf adds a condition for the field if 'cond' is true, even if 'value' is the zero value,
+// such as to filter on an explicit false.
+func (c *Conditions) AddIf(field string, cond bool, value any) *Conditions {
+	if cond {
+		c.set(field, value)
+	}
+	return c
+}
+
+func (c *Conditions) set(field string, value any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	if _, exists := c.values[field]; !exists {
+		c.fields = append(c.fields, field)
+	}
+	c.values[field] = value
+}
+
+// Len returns the number of conditions.
+func (c *Conditions) Len() int {
+	return len(c.fields)
+}
+
+// Fields returns the names of the fields with conditions, in the order they were added.
+func (c *Conditions) Fields() []string {
+	return Freeze(c.fields)
+}
+
+// Map returns the conditions as a map from field to value.
+func (c *Conditions) Map() map[string]any {
+	return FreezeMap(c.values)
+}
+
+// Join renders the conditions in the order they were added, formatting each value with fmt.Sprint.
+//
+// Parameters:
+//   - kvSep: The separator between a field and its value, such as "=".
+//   - sep: The separator between conditions, such as "&" or " AND ".
+//
+// Returns:
+//   - string: The rendered conditions, or an empty string if there are none.
+func (c *Conditions) Join(kvSep, sep string) string {
+	parts := Map(c.fields, func(field string) string {
+		return field + kvSep + fmt.Sprint(c.values[field])
+	})
+	return strings.Join(parts, sep)
+}
+
+// conditionValue dereferences pointers, and returns false for nil, empty and zero values.
+func conditionValue(value any) (any, bool) {
+	rv := reflect.ValueOf(value)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.IsZero() {
+		return nil, false
+	}
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return nil, false
+	}
+	return rv.Interface(), true
+}
@@ -0,0 +1,82 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions(t *testing.T) {
+	type listRequest struct {
+		Status    string
+		Owner     *string
+		AccountID *string
+		Limit     int
+		Tags      []string
+	}
+
+	tests := []struct {
+		name     string
+		req      listRequest
+		wantJoin string
+		wantMap  map[string]any
+	}{
+		{
+			name:     "zero values skipped",
+			req:      listRequest{Status: "active", Owner: grab.Ptr("alice")},
+			wantJoin: "status = active AND owner = alice",
+			wantMap:  map[string]any{"status": "active", "owner": "alice"},
+		},
+		{
+			name:     "pointer to zero value skipped",
+			req:      listRequest{Owner: grab.Ptr(""), Limit: 10},
+			wantJoin: "limit = 10",
+			wantMap:  map[string]any{"limit": 10},
+		},
+		{
+			name:     "empty slice skipped",
+			req:      listRequest{Tags: []string{}},
+			wantJoin: "",
+			wantMap:  nil,
+		},
+		{
+			name:     "slice",
+			req:      listRequest{Tags: []string{"prod"}},
+			wantJoin: "tags = [prod]",
+			wantMap:  map[string]any{"tags": []string{"prod"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var where grab.Conditions
+			where.Add("status", tt.req.Status).
+				Add("owner", tt.req.Owner).
+				Add("account_id", tt.req.AccountID).
+				Add("limit", tt.req.Limit).
+				Add("tags", tt.req.Tags)
+
+			assert.Equal(t, tt.wantJoin, where.Join(" = ", " AND "))
+			assert.Equal(t, tt.wantMap, where.Map())
+			assert.Equal(t, len(tt.wantMap), where.Len())
+		})
+	}
+}
+
+func TestConditionsAddIf(t *testing.T) {
+	var where grab.Conditions
+	where.AddIf("archived", true, false).
+		AddIf("deleted", false, true).
+		Add("nil", nil)
+
+	assert.Equal(t, "archived=false", where.Join("=", "&"))
+}
+
+func TestConditionsReplace(t *testing.T) {
+	var where grab.Conditions
+	where.Add("a", 1).Add("b", 2).Add("a", 3)
+
+	assert.Equal(t, []string{"a", "b"}, where.Fields())
+	assert.Equal(t, "a:3,b:2", where.Join(":", ","))
+}
@@ -0,0 +1,128 @@
+package grab
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// COWSlice is a copy-on-write slice. Reads are lock-free and return the current snapshot,
+// while writes copy the slice and atomically swap in the new version.
+// It is safe for concurrent use, and the zero value is an empty COWSlice.
+//
+// Example:
+// var entitlements COWSlice[Entitlement]
+// entitlements.Store(fetched)           // in the background refresher
+// for _, e := range entitlements.Load() // in request handlers
+//
+// Note: This type is useful for read-heavy data which is replaced or updated infrequently,
+// where taking a lock on every read would be too expensive.
+type COWSlice[T any] struct {
+	mu sync.Mutex // serializes writers
+	p  atomic.Pointer[[]T]
+}
+
+// Load returns the current snapshot of the slice. The returned slice is shared with other readers
+// and must not be modified.
+func (s *COWSlice[T]) Load() []T {
+	p := s.p.Load()
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// Len returns the length of the current snapshot.
+func (s *COWSlice[T]) Len() int {
+	return len(s.Load())
+}
+
+// Store replaces the slice with a copy of 'items'.
+func (s *COWSlice[T]) Store(items []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := Freeze(items)
+	s.p.Store(&next)
+}
+
+// Append adds items to the end of the slice.
+func (s *COWSlice[T]) Append(items ...T) {
+	s.Update(func(current []T) []T {
+		return append(current, items...)
+	})
+}
+
+// Update replaces the slice with the result of 'fn', which receives a copy of the current
+// slice that it is free to modify. Concurrent updates are applied one at a time.
+func (s *COWSlice[T]) Update(fn func(current []T) []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := fn(Freeze(s.Load()))
+	s.p.Store(&next)
+}
+
+// COWMap is a copy-on-write map. Reads are lock-free and use the current snapshot,
+// while writes copy the map and atomically swap in the new version.
+// It is safe for concurrent use, and the zero value is an empty COWMap.
+//
+// Note: Each write copies the whole map, so this type is suited to read-heavy data with infrequent,
+// batched updates. Prefer Update over repeated calls to Set when changing several keys.
+type COWMap[K comparable, V any] struct {
+	mu sync.Mutex // serializes writers
+	p  atomic.Pointer[map[K]V]
+}
+
+// Load returns the current snapshot of the map. The returned map is shared with other readers
+// and must not be modified.
+func (m *COWMap[K, V]) Load() map[K]V {
+	p := m.p.Load()
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// Get returns the value for a key in the current snapshot, and whether it was present.
+func (m *COWMap[K, V]) Get(key K) (V, bool) {
+	v, ok := m.Load()[key]
+	return v, ok
+}
+
+// Len returns the number of entries in the current snapshot.
+func (m *COWMap[K, V]) Len() int {
+	return len(m.Load())
+}
+
+// Store replaces the map with a copy of 'items'.
+func (m *COWMap[K, V]) Store(items map[K]V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	next := FreezeMap(items)
+	m.p.Store(&next)
+}
+
+// Set sets the value for a key.
+func (m *COWMap[K, V]) Set(key K, value V) {
+	m.Update(func(current map[K]V) {
+		current[key] = value
+	})
+}
+
+// Delete removes a key.
+func (m *COWMap[K, V]) Delete(key K) {
+	m.Update(func(current map[K]V) {
+		delete(current, key)
+	})
+}
+
+// Update modifies the map with 'fn', which receives a copy of the current map that it is free to modify.
+// Concurrent updates are applied one at a time.
+func (m *COWMap[K, V]) Update(fn func(current map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	next := FreezeMap(m.Load())
+	if next == nil {
+		next = make(map[K]V)
+	}
+	fn(next)
+	m.p.Store(&next)
+}
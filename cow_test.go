@@ -0,0 +1,73 @@
+package grab_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOWSlice(t *testing.T) {
+	var s grab.COWSlice[int]
+	assert.Nil(t, s.Load())
+
+	items := []int{1, 2}
+	s.Store(items)
+	items[0] = 100
+	snapshot := s.Load()
+	assert.Equal(t, []int{1, 2}, snapshot)
+
+	s.Append(3)
+	assert.Equal(t, []int{1, 2}, snapshot, "earlier snapshots are not affected by writes")
+	assert.Equal(t, []int{1, 2, 3}, s.Load())
+	assert.Equal(t, 3, s.Len())
+
+	s.Update(func(current []int) []int {
+		current[0] = 10
+		return current[:2]
+	})
+	assert.Equal(t, []int{10, 2}, s.Load())
+}
+
+func TestCOWSliceConcurrentAppend(t *testing.T) {
+	var s grab.COWSlice[int]
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Append(i)
+			_ = s.Len()
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, s.Len())
+}
+
+func TestCOWMap(t *testing.T) {
+	var m grab.COWMap[string, int]
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+
+	m.Set("a", 1)
+	snapshot := m.Load()
+	m.Set("b", 2)
+	assert.Equal(t, map[string]int{"a": 1}, snapshot, "earlier snapshots are not affected by writes")
+
+	v, ok := m.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	m.Delete("a")
+	assert.Equal(t, map[string]int{"b": 2}, m.Load())
+
+	m.Store(map[string]int{"c": 3, "d": 4})
+	assert.Equal(t, 2, m.Len())
+
+	m.Update(func(current map[string]int) {
+		current["c"]++
+		delete(current, "d")
+	})
+	assert.Equal(t, map[string]int{"c": 4}, m.Load())
+}
@@ -0,0 +1,87 @@
+package grab
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadLetterEntry records an item which could not be processed.
+type DeadLetterEntry[T any] struct {
+	// Item is the item which could not be processed.
+	Item T
+	// Err is the error from the last attempt.
+	Err error
+	// Attempts is the number of times processing the item was attempted.
+	Attempts int
+	// FailedAt is when the item was added to the DeadLetter.
+	FailedAt time.Time
+}
+
+// DeadLetter collects items which could not be processed, so that a pipeline can carry on past partial failures
+// and persist or retry the failed items at the end, rather than dropping them or failing outright.
+// It is safe for concurrent use.
+//
+// Example:
+// dlq := NewDeadLetter[Message]()
+// sink := Sink[Message]{WriteBatch: sendBatch, DeadLetter: dlq}
+//
+//	if err := sink.WriteAll(ctx, messages); err != nil {
+//	    return err
+//	}
+//	for _, entry := range dlq.Drain() {
+//	    log.Printf("failed to send %v after %d attempts: %v", entry.Item, entry.Attempts, entry.Err)
+//	}
+type DeadLetter[T any] struct {
+	clock Clock
+
+	mu      sync.Mutex
+	entries []DeadLetterEntry[T]
+}
+
+// NewDeadLetter creates an empty DeadLetter.
+//
+// Parameters:
+//   - opts: Optional settings. WithClock sets the Clock used to record when items failed.
+//
+// Returns:
+//   - *DeadLetter[T]: A new DeadLetter with no entries.
+func NewDeadLetter[T any](opts ...Option[Config]) *DeadLetter[T] {
+	cfg := newConfig(opts)
+	return &DeadLetter[T]{clock: cfg.Clock}
+}
+
+// Add records an item which failed with 'err' after the given number of attempts.
+func (d *DeadLetter[T]) Add(item T, err error, attempts int) {
+	entry := DeadLetterEntry[T]{Item: item, Err: err, Attempts: attempts, FailedAt: d.clock.Now()}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, entry)
+}
+
+// Len returns the number of entries.
+func (d *DeadLetter[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.entries)
+}
+
+// Entries returns a copy of the entries, in the order they were added.
+func (d *DeadLetter[T]) Entries() []DeadLetterEntry[T] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return Freeze(d.entries)
+}
+
+// Items returns the failed items, in the order they were added, such as to retry them.
+func (d *DeadLetter[T]) Items() []T {
+	return Map(d.Entries(), func(e DeadLetterEntry[T]) T { return e.Item })
+}
+
+// Drain removes and returns every entry, in the order they were added.
+func (d *DeadLetter[T]) Drain() []DeadLetterEntry[T] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entries := d.entries
+	d.entries = nil
+	return entries
+}
@@ -0,0 +1,78 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetter(t *testing.T) {
+	errFailed := errors.New("failed")
+	dlq := grab.NewDeadLetter[string](grab.WithClock(grab.NewFakeClock(epoch)))
+
+	dlq.Add("a", errFailed, 3)
+	dlq.Add("b", errFailed, 1)
+
+	assert.Equal(t, 2, dlq.Len())
+	assert.Equal(t, []string{"a", "b"}, dlq.Items())
+	assert.Equal(t, []grab.DeadLetterEntry[string]{
+		{Item: "a", Err: errFailed, Attempts: 3, FailedAt: epoch},
+		{Item: "b", Err: errFailed, Attempts: 1, FailedAt: epoch},
+	}, dlq.Drain())
+
+	assert.Equal(t, 0, dlq.Len())
+	assert.Nil(t, dlq.Drain())
+}
+
+func TestDeadLetterConcurrent(t *testing.T) {
+	dlq := grab.NewDeadLetter[int]()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dlq.Add(i, errors.New("failed"), 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, seqInts(50), dlq.Items())
+}
+
+func TestSinkDeadLetter(t *testing.T) {
+	errTransient := errors.New("transient")
+	dlq := grab.NewDeadLetter[int](grab.WithClock(grab.NewFakeClock(epoch)))
+
+	sink := grab.Sink[int]{
+		BatchSize:  2,
+		Retry:      grab.RetryPolicy{MaxAttempts: 2},
+		DeadLetter: dlq,
+		WriteBatch: func(ctx context.Context, batch []int) ([]grab.ItemError[int], error) {
+			var failed []grab.ItemError[int]
+			for _, item := range batch {
+				switch item {
+				case 1:
+					failed = append(failed, grab.ItemError[int]{Item: item, Err: errTransient})
+				case 2:
+					failed = append(failed, grab.ItemError[int]{Item: item, Err: &grab.HTTPStatusError{StatusCode: 404}})
+				}
+			}
+			return failed, nil
+		},
+	}
+
+	clock := &instantClock{Clock: grab.NewFakeClock(epoch)}
+	err := sink.WriteAll(context.Background(), seqInts(4), grab.WithClock(clock))
+	assert.NoError(t, err, "failed items should be sent to the dead letter rather than returned")
+
+	entries := dlq.Entries()
+	assert.ElementsMatch(t, []grab.DeadLetterEntry[int]{
+		{Item: 1, Err: errTransient, Attempts: 2, FailedAt: epoch},
+		{Item: 2, Err: &grab.HTTPStatusError{StatusCode: 404}, Attempts: 1, FailedAt: epoch},
+	}, entries)
+}
@@ -0,0 +1,104 @@
+package grab
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ErrUnsupportedCompression is returned by AutoDecompress when the input is compressed
+// in a recognised format which has no registered decompressor.
+var ErrUnsupportedCompression = errors.New("unsupported compression format")
+
+type decompressor struct {
+	name  string
+	magic []byte
+	fn    func(r io.Reader) (io.Reader, error)
+}
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = []decompressor{
+		{name: "gzip", magic: []byte{0x1f, 0x8b}, fn: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		// the standard library has no zstd decoder, so zstd is detected but must be registered by the caller
+		{name: "zstd", magic: []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	}
+)
+
+// RegisterDecompressor registers a decompressor used by AutoDecompress for input beginning with 'magic'.
+// Registering a decompressor with the same name as an existing one replaces it.
+//
+// Parameters:
+//   - name: The name of the compression format, such as "zstd".
+//   - magic: The magic bytes that input in this format begins with.
+//   - fn: A function that returns a reader of the decompressed data.
+//
+// Example:
+//
+//	grab.RegisterDecompressor("zstd", []byte{0x28, 0xb5, 0x2f, 0xfd}, func(r io.Reader) (io.Reader, error) {
+//	    return zstd.NewReader(r)
+//	})
+func RegisterDecompressor(name string, magic []byte, fn func(r io.Reader) (io.Reader, error)) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+	// copy rather than modifying in place, as AutoDecompress may be reading the current slice
+	ds := slices.DeleteFunc(Freeze(decompressors), func(d decompressor) bool { return d.name == name })
+	decompressors = append(ds, decompressor{name: name, magic: bytes.Clone(magic), fn: fn})
+}
+
+// AutoDecompress detects whether the input is compressed by sniffing its magic bytes, and returns a reader
+// of the decompressed data. Uncompressed input is returned unchanged.
+// Gzip is supported by default, and other formats can be supported with RegisterDecompressor.
+//
+// Parameters:
+//   - r: The reader of possibly compressed data.
+//
+// Returns:
+//   - io.Reader: A reader of the decompressed data.
+//   - error: An error wrapping ErrUnsupportedCompression if the input is compressed in a recognised format
+//     without a registered decompressor (such as zstd), or an error from reading the input or creating the decompressor.
+//
+// Example:
+//
+//	r, err := AutoDecompress(file)
+//	if err != nil {
+//	    return err
+//	}
+//	for line, err := range ReadLines(r) { ... }
+//
+// Note: Readers returned by decompressors which implement io.Closer are not closed by this function.
+func AutoDecompress(r io.Reader) (io.Reader, error) {
+	decompressorsMu.RLock()
+	ds := decompressors
+	decompressorsMu.RUnlock()
+
+	n := 0
+	for _, d := range ds {
+		n = max(n, len(d.magic))
+	}
+
+	br := bufio.NewReader(r)
+	header, err := br.Peek(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var match *decompressor
+	for i, d := range ds {
+		if bytes.HasPrefix(header, d.magic) && (match == nil || len(d.magic) > len(match.magic)) {
+			match = &ds[i]
+		}
+	}
+	if match == nil {
+		return br, nil
+	}
+	if match.fn == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, match.name)
+	}
+	return match.fn(br)
+}
@@ -0,0 +1,66 @@
+package grab_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestAutoDecompress(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    string
+		wantErr error
+	}{
+		{name: "empty", input: nil, want: ""},
+		{name: "short uncompressed", input: []byte("a"), want: "a"},
+		{name: "uncompressed", input: []byte("{\"id\":1}\n"), want: "{\"id\":1}\n"},
+		{name: "gzip", input: gzipped(t, "{\"id\":1}\n"), want: "{\"id\":1}\n"},
+		{name: "zstd without decompressor", input: []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, wantErr: grab.ErrUnsupportedCompression},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := grab.AutoDecompress(bytes.NewReader(tt.input))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			got, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRegisterDecompressor(t *testing.T) {
+	magic := []byte("UPPER:")
+	grab.RegisterDecompressor("upper", magic, func(r io.Reader) (io.Reader, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(strings.ToUpper(string(bytes.TrimPrefix(b, magic)))), nil
+	})
+
+	r, err := grab.AutoDecompress(strings.NewReader("UPPER:hello"))
+	assert.NoError(t, err)
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "HELLO", string(got))
+}
@@ -0,0 +1,99 @@
+package grab
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue holds items until their scheduled time, and then makes them available in the order they are due.
+// Items scheduled for the same time are taken in the order they were pushed. It is safe for concurrent use.
+//
+// Example:
+// expiries := NewDelayQueue[Grant]()
+//
+// expiries.Push(grant, grant.ExpiresAt)
+//
+//	for {
+//	    grant, err := expiries.Pop(ctx) // blocks until the grant expires
+//	    if err != nil {
+//	        return err
+//	    }
+//	    revoke(ctx, grant)
+//	}
+//
+// Note: A single timer is used for the earliest item, so a DelayQueue scales to many scheduled items
+// without creating a timer for each of them.
+type DelayQueue[T any] struct {
+	cfg Config
+
+	mu    sync.Mutex
+	items *priorityHeap[delayedItem[T]]
+	// changed is closed and replaced whenever an item is pushed, waking consumers to re-check the earliest item.
+	changed chan struct{}
+}
+
+type delayedItem[T any] struct {
+	value T
+	at    time.Time
+}
+
+// NewDelayQueue creates an empty DelayQueue.
+//
+// Parameters:
+//   - opts: Optional settings. WithClock sets the Clock used to tell when items are due.
+//
+// Returns:
+//   - *DelayQueue[T]: A new, empty DelayQueue.
+func NewDelayQueue[T any](opts ...Option[Config]) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		cfg:     newConfig(opts),
+		items:   newPriorityHeap(func(a, b delayedItem[T]) bool { return a.at.Before(b.at) }),
+		changed: make(chan struct{}),
+	}
+}
+
+// Push schedules an item to become available at the given time. Items scheduled in the past are available immediately.
+func (q *DelayQueue[T]) Push(value T, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.push(delayedItem[T]{value: value, at: at})
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// Pop removes and returns the earliest item, blocking until it is due.
+// It returns the context error if the context is cancelled first.
+func (q *DelayQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		changed := q.changed
+		var timer <-chan time.Time
+		if q.items.Len() > 0 {
+			head := q.items.peek()
+			wait := head.at.Sub(q.cfg.Clock.Now())
+			if wait <= 0 {
+				q.items.pop()
+				q.mu.Unlock()
+				return head.value, nil
+			}
+			timer = q.cfg.Clock.After(wait)
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-timer:
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of items in the queue, including items which are not yet due.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
@@ -0,0 +1,58 @@
+package grab_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayQueue(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	queue := grab.NewDelayQueue[string](grab.WithClock(clock))
+
+	queue.Push("in an hour", epoch.Add(time.Hour))
+	queue.Push("in a minute", epoch.Add(time.Minute))
+	queue.Push("overdue", epoch.Add(-time.Minute))
+	assert.Equal(t, 3, queue.Len())
+
+	got, err := queue.Pop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "overdue", got)
+
+	popped := make(chan string)
+	go func() {
+		for i := 0; i < 2; i++ {
+			v, err := queue.Pop(context.Background())
+			assert.NoError(t, err)
+			popped <- v
+		}
+	}()
+
+	select {
+	case v := <-popped:
+		t.Fatalf("item %q popped before it was due", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, "in a minute", receive(t, popped))
+
+	// an item pushed while Pop is waiting is returned if it is due sooner
+	queue.Push("now", clock.Now())
+	assert.Equal(t, "now", receive(t, popped))
+	assert.Equal(t, 1, queue.Len())
+}
+
+func TestDelayQueuePopCancelled(t *testing.T) {
+	queue := grab.NewDelayQueue[int](grab.WithClock(grab.NewFakeClock(epoch)))
+	queue.Push(1, epoch.Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, queue.Len())
+}
@@ -0,0 +1,123 @@
+package grab
+
+import "fmt"
+
+// EditOp is the kind of change described by an Edit.
+type EditOp int
+
+const (
+	// EditKeep means the value is present in both slices.
+	EditKeep EditOp = iota
+	// EditDelete means the value is only present in the first slice.
+	EditDelete
+	// EditInsert means the value is only present in the second slice.
+	EditInsert
+)
+
+// String returns the diff marker for the operation: " ", "-" or "+".
+func (op EditOp) String() string {
+	switch op {
+	case EditDelete:
+		return "-"
+	case EditInsert:
+		return "+"
+	default:
+		return " "
+	}
+}
+
+// Edit is a single step in an edit script returned by EditScript.
+type Edit[T any] struct {
+	// Op is the kind of change.
+	Op EditOp
+	// Value is the value kept, deleted or inserted.
+	Value T
+}
+
+// String renders the edit as a line of a unified diff, such as "+ s3:GetObject".
+func (e Edit[T]) String() string {
+	return fmt.Sprintf("%s %v", e.Op, e.Value)
+}
+
+// EditScript returns a minimal sequence of edits which transforms slice 'a' into slice 'b',
+// based on their longest common subsequence. The edits are ordered, so they can be rendered as a diff.
+//
+// Parameters:
+//   - a: The original slice.
+//   - b: The updated slice.
+//
+// Returns:
+//   - []Edit[T]: The edits in order. Keeping the EditKeep and EditDelete values yields 'a', and keeping the
+//     EditKeep and EditInsert values yields 'b'. Where a value is replaced, the deletion comes before the insertion.
+//
+// Example:
+// edits := EditScript([]string{"s3:GetObject", "s3:PutObject"}, []string{"s3:GetObject", "s3:ListBucket"})
+//
+//	for _, e := range edits {
+//	    fmt.Println(e)
+//	}
+//
+// // prints:
+// //   s3:GetObject
+// // - s3:PutObject
+// // + s3:ListBucket
+//
+// Note: Common prefixes and suffixes are matched in linear time. The remaining middle section uses O(n*m) time and memory,
+// which suits lists of up to a few thousand items such as policy statements.
+func EditScript[T comparable](a, b []T) []Edit[T] {
+	var edits []Edit[T]
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		edits = append(edits, Edit[T]{Op: EditKeep, Value: a[prefix]})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			edits = append(edits, Edit[T]{Op: EditKeep, Value: midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit[T]{Op: EditDelete, Value: midA[i]})
+			i++
+		default:
+			edits = append(edits, Edit[T]{Op: EditInsert, Value: midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		edits = append(edits, Edit[T]{Op: EditDelete, Value: midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		edits = append(edits, Edit[T]{Op: EditInsert, Value: midB[j]})
+	}
+
+	for _, v := range a[len(a)-suffix:] {
+		edits = append(edits, Edit[T]{Op: EditKeep, Value: v})
+	}
+	return edits
+}
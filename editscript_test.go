@@ -0,0 +1,58 @@
+package grab_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func renderEdits[T any](edits []grab.Edit[T]) string {
+	return strings.Join(grab.Map(edits, grab.Edit[T].String), "\n")
+}
+
+func TestEditScript(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want string
+	}{
+		{name: "both empty", want: ""},
+		{name: "identical", a: []string{"a", "b"}, b: []string{"a", "b"}, want: "  a\n  b"},
+		{name: "all inserted", b: []string{"a", "b"}, want: "+ a\n+ b"},
+		{name: "all deleted", a: []string{"a", "b"}, want: "- a\n- b"},
+		{
+			name: "replacement",
+			a:    []string{"s3:GetObject", "s3:PutObject"},
+			b:    []string{"s3:GetObject", "s3:ListBucket"},
+			want: "  s3:GetObject\n- s3:PutObject\n+ s3:ListBucket",
+		},
+		{
+			name: "moves and changes",
+			a:    []string{"a", "b", "c", "d", "e"},
+			b:    []string{"a", "c", "x", "d", "b", "e"},
+			want: "  a\n- b\n  c\n+ x\n  d\n+ b\n  e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := grab.EditScript(tt.a, tt.b)
+			assert.Equal(t, tt.want, renderEdits(edits))
+
+			// applying the edits should reproduce both slices
+			var gotA, gotB []string
+			for _, e := range edits {
+				if e.Op != grab.EditInsert {
+					gotA = append(gotA, e.Value)
+				}
+				if e.Op != grab.EditDelete {
+					gotB = append(gotB, e.Value)
+				}
+			}
+			assert.Equal(t, tt.a, gotA)
+			assert.Equal(t, tt.b, gotB)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package grab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ErrInvalidEnum is returned when a value is not one of the values of an Enum.
+var ErrInvalidEnum = errors.New("invalid enum value")
+
+// Enum is the set of valid values of an enum type, used to validate and parse values of that type.
+// It is safe for concurrent use.
+type Enum[T ~string | ~int] struct {
+	values []T
+	set    map[T]struct{}
+}
+
+// EnumValues creates an Enum from the valid values of an enum type.
+//
+// Parameters:
+//   - vals: The valid values, in the order they should be listed.
+//
+// Returns:
+//   - *Enum[T]: An Enum containing the values.
+//
+// Example:
+//
+//	type Status string
+//
+//	const (
+//	    StatusActive  Status = "active"
+//	    StatusRevoked Status = "revoked"
+//	)
+//
+//	var Statuses = EnumValues(StatusActive, StatusRevoked)
+//
+//	func (s *Status) UnmarshalJSON(data []byte) error {
+//	    return Statuses.DecodeJSON(data, s)
+//	}
+func EnumValues[T ~string | ~int](vals ...T) *Enum[T] {
+	return &Enum[T]{values: slices.Clone(vals), set: MapFromSlice(vals, struct{}{})}
+}
+
+// Values returns the valid values, in the order they were provided to EnumValues.
+func (e *Enum[T]) Values() []T {
+	return slices.Clone(e.values)
+}
+
+// IsValid reports whether the value is one of the valid values.
+func (e *Enum[T]) IsValid(v T) bool {
+	_, ok := e.set[v]
+	return ok
+}
+
+// Parse returns the valid value whose string form (as formatted by fmt.Sprint) is 's'.
+// For integer enums with a String method, this parses the name of the value.
+// It returns an error wrapping ErrInvalidEnum if no valid value matches.
+func (e *Enum[T]) Parse(s string) (T, error) {
+	for _, v := range e.values {
+		if fmt.Sprint(v) == s {
+			return v, nil
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: %q is not one of %v", ErrInvalidEnum, s, e.values)
+}
+
+// MustParse behaves like Parse, but panics if the value is not valid.
+// It is intended for constants and tests, where an invalid value is a programming error.
+func (e *Enum[T]) MustParse(s string) T {
+	v, err := e.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// DecodeJSON decodes a JSON string (for string enums) or number (for integer enums) into 'dst',
+// returning an error wrapping ErrInvalidEnum if it is not one of the valid values.
+// It is intended to be called from an UnmarshalJSON method on the enum type, and decodes into the underlying
+// type so that it doesn't call that method recursively.
+func (e *Enum[T]) DecodeJSON(data []byte, dst *T) error {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		rv.SetString(s)
+	default:
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	}
+
+	if !e.IsValid(v) {
+		return fmt.Errorf("%w: %s is not one of %v", ErrInvalidEnum, data, e.values)
+	}
+	*dst = v
+	return nil
+}
@@ -0,0 +1,93 @@
+package grab_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type status string
+
+const (
+	statusActive  status = "active"
+	statusRevoked status = "revoked"
+)
+
+var statuses = grab.EnumValues(statusActive, statusRevoked)
+
+func (s *status) UnmarshalJSON(data []byte) error {
+	return statuses.DecodeJSON(data, s)
+}
+
+type level int
+
+const (
+	levelLow level = iota + 1
+	levelHigh
+)
+
+func (l level) String() string {
+	switch l {
+	case levelLow:
+		return "low"
+	case levelHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+var levels = grab.EnumValues(levelLow, levelHigh)
+
+func (l *level) UnmarshalJSON(data []byte) error {
+	return levels.DecodeJSON(data, l)
+}
+
+func TestEnum(t *testing.T) {
+	assert.Equal(t, []status{statusActive, statusRevoked}, statuses.Values())
+	assert.True(t, statuses.IsValid("active"))
+	assert.False(t, statuses.IsValid("deleted"))
+
+	got, err := statuses.Parse("revoked")
+	assert.NoError(t, err)
+	assert.Equal(t, statusRevoked, got)
+
+	_, err = statuses.Parse("deleted")
+	assert.ErrorIs(t, err, grab.ErrInvalidEnum)
+	assert.EqualError(t, err, `invalid enum value: "deleted" is not one of [active revoked]`)
+	assert.Panics(t, func() { statuses.MustParse("deleted") })
+
+	// integer enums are parsed by their String method
+	assert.Equal(t, levelHigh, levels.MustParse("high"))
+}
+
+func TestEnumDecodeJSON(t *testing.T) {
+	type grant struct {
+		Status status `json:"status"`
+		Level  level  `json:"level"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    grant
+		wantErr error
+	}{
+		{name: "valid", input: `{"status":"active","level":2}`, want: grant{Status: statusActive, Level: levelHigh}},
+		{name: "invalid string", input: `{"status":"deleted","level":1}`, wantErr: grab.ErrInvalidEnum},
+		{name: "invalid int", input: `{"status":"active","level":7}`, wantErr: grab.ErrInvalidEnum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got grant
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
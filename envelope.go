@@ -0,0 +1,72 @@
+package grab
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Envelope is a standard JSON shape for a page of results from a paginated endpoint,
+// so that services serving and consuming paginated endpoints can share one representation.
+//
+// It is serialized as:
+//
+//	{"items": [...], "nextToken": "abc", "total": 42}
+//
+// where "nextToken" is omitted on the last page and "total" is omitted if it is unknown.
+type Envelope[T any] struct {
+	// Items are the results on this page.
+	Items []T `json:"items"`
+	// NextToken is the token for the next page, or empty if this is the last page.
+	NextToken string `json:"nextToken,omitempty"`
+	// Total is the total number of results across all pages, or nil if it is unknown.
+	Total *int `json:"total,omitempty"`
+}
+
+// NewEnvelope creates an Envelope for a page of results.
+//
+// Parameters:
+//   - items: The results on this page. A nil slice is replaced by an empty slice, so it is serialized as [] rather than null.
+//   - nextToken: The token for the next page, or empty if this is the last page.
+//
+// Returns:
+//   - Envelope[T]: An Envelope containing the page of results.
+//
+// Example:
+// env := NewEnvelope(users, nextToken)
+// env.Total = Ptr(count)
+// json.NewEncoder(w).Encode(env)
+func NewEnvelope[T any](items []T, nextToken string) Envelope[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Envelope[T]{Items: items, NextToken: nextToken}
+}
+
+// Page returns the items and next token in the shape expected by the fetchPage function passed to AllPages,
+// where a nil token indicates that there are no more pages.
+//
+// Example:
+//
+//	users, err := AllPages(ctx, func(ctx context.Context, token *string) ([]User, *string, error) {
+//	    env, err := client.ListUsers(ctx, Value(token))
+//	    if err != nil {
+//	        return nil, nil, err
+//	    }
+//	    return env.Page()
+//	})
+func (e Envelope[T]) Page() ([]T, *string, error) {
+	if e.NextToken == "" {
+		return e.Items, nil, nil
+	}
+	return e.Items, &e.NextToken, nil
+}
+
+// DecodeEnvelope reads an Envelope from a JSON response body, returning its items and next token.
+// It is used by JSONPager when no Decode function is provided.
+func DecodeEnvelope[T any](body io.Reader) ([]T, string, error) {
+	var env Envelope[T]
+	if err := json.NewDecoder(body).Decode(&env); err != nil {
+		return nil, "", err
+	}
+	return env.Items, env.NextToken, nil
+}
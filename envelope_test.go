@@ -0,0 +1,68 @@
+package grab_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelopeJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		env  grab.Envelope[int]
+		want string
+	}{
+		{name: "last page", env: grab.NewEnvelope([]int{1, 2}, ""), want: `{"items":[1,2]}`},
+		{name: "empty page", env: grab.NewEnvelope[int](nil, ""), want: `{"items":[]}`},
+		{name: "next token and total", env: grab.Envelope[int]{Items: []int{1}, NextToken: "abc", Total: grab.Ptr(3)}, want: `{"items":[1],"nextToken":"abc","total":3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.env)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+
+			items, token, err := grab.DecodeEnvelope[int](strings.NewReader(tt.want))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.env.Items, items)
+			assert.Equal(t, tt.env.NextToken, token)
+		})
+	}
+}
+
+func TestEnvelopePage(t *testing.T) {
+	pages := map[string]grab.Envelope[int]{
+		"":  grab.NewEnvelope([]int{1, 2}, "b"),
+		"b": grab.NewEnvelope([]int{3}, ""),
+	}
+	got, err := grab.AllPages(context.Background(), func(ctx context.Context, token *string) ([]int, *string, error) {
+		return pages[grab.Value(token)].Page()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestJSONPagerEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		env := grab.NewEnvelope([]string{"alice"}, "2")
+		if r.URL.Query().Get("pageToken") == "2" {
+			env = grab.NewEnvelope([]string{"bob"}, "")
+		}
+		_ = json.NewEncoder(w).Encode(env)
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	assert.NoError(t, err)
+	pager := grab.JSONPager[string]{Request: req}
+
+	got, err := pager.AllPages(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, got)
+}
@@ -0,0 +1,119 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// ErrorKind is a category of error which integrations can register classifiers for with RegisterErrorClassifier.
+type ErrorKind int
+
+const (
+	// ErrorRetryable is a transient error, where retrying the operation may succeed.
+	ErrorRetryable ErrorKind = iota
+	// ErrorNotFound is an error caused by a missing resource.
+	ErrorNotFound
+	// ErrorThrottle is an error caused by rate limiting. Throttling errors are also retryable.
+	ErrorThrottle
+)
+
+var (
+	classifiersMu sync.RWMutex
+	classifiers   = map[ErrorKind][]func(err error) bool{
+		ErrorRetryable: {isBuiltinRetryable},
+		ErrorNotFound:  {isBuiltinNotFound},
+		ErrorThrottle:  {isBuiltinThrottle},
+	}
+)
+
+// RegisterErrorClassifier registers a function which reports whether an error is of the given kind,
+// so that IsRetryable, IsNotFound and IsThrottle recognise errors from an SDK or integration.
+// Classifiers are called with the original error, so they should use errors.Is or errors.As to inspect wrapped errors.
+//
+// Parameters:
+//   - kind: The kind of error the classifier detects.
+//   - fn: A function returning true if the error is of the given kind.
+//
+// Example:
+//
+//	grab.RegisterErrorClassifier(grab.ErrorThrottle, func(err error) bool {
+//	    var apiErr smithy.APIError
+//	    return errors.As(err, &apiErr) && apiErr.ErrorCode() == "ThrottlingException"
+//	})
+//
+// Note: Classifiers are usually registered in an init function, and are shared by the whole program.
+func RegisterErrorClassifier(kind ErrorKind, fn func(err error) bool) {
+	classifiersMu.Lock()
+	defer classifiersMu.Unlock()
+	// copy rather than appending in place, as classify may be iterating over the current slice
+	classifiers[kind] = append(Freeze(classifiers[kind]), fn)
+}
+
+func classify(kind ErrorKind, err error) bool {
+	if err == nil {
+		return false
+	}
+	classifiersMu.RLock()
+	fns := classifiers[kind]
+	classifiersMu.RUnlock()
+	for _, fn := range fns {
+		if fn(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable reports whether an error is transient, so that retrying the operation may succeed.
+// Throttling errors, timeouts, and HTTP 408, 429 and 5xx responses are retryable, as are errors
+// matched by classifiers registered for ErrorRetryable. Context cancellation is never retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return classify(ErrorRetryable, err) || IsThrottle(err)
+}
+
+// IsNotFound reports whether an error is caused by a missing resource.
+// fs.ErrNotExist and HTTP 404 responses are not found errors, as are errors matched by
+// classifiers registered for ErrorNotFound.
+func IsNotFound(err error) bool {
+	return classify(ErrorNotFound, err)
+}
+
+// IsThrottle reports whether an error is caused by rate limiting.
+// Errors implementing RetryAfterer with a non-zero delay and HTTP 429 responses are throttling errors, as are errors
+// matched by classifiers registered for ErrorThrottle.
+func IsThrottle(err error) bool {
+	return classify(ErrorThrottle, err)
+}
+
+func isBuiltinRetryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusRequestTimeout || statusErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isBuiltinNotFound(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+func isBuiltinThrottle(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	var ra RetryAfterer
+	return errors.As(err, &ra) && ra.RetryAfter() > 0
+}
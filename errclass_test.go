@@ -0,0 +1,60 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+var errQuotaExceeded = errors.New("QuotaExceeded")
+
+func init() {
+	grab.RegisterErrorClassifier(grab.ErrorThrottle, func(err error) bool {
+		return errors.Is(err, errQuotaExceeded)
+	})
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+		wantNotFound  bool
+		wantThrottle  bool
+	}{
+		{name: "nil", err: nil},
+		{name: "plain error", err: errors.New("mock")},
+		{name: "context cancelled", err: context.Canceled},
+		{name: "http 500", err: &grab.HTTPStatusError{StatusCode: http.StatusInternalServerError}, wantRetryable: true},
+		{name: "http 400", err: &grab.HTTPStatusError{StatusCode: http.StatusBadRequest}},
+		{name: "http 404", err: &grab.HTTPStatusError{StatusCode: http.StatusNotFound}, wantNotFound: true},
+		{name: "http 429", err: &grab.HTTPStatusError{StatusCode: http.StatusTooManyRequests}, wantRetryable: true, wantThrottle: true},
+		{name: "retry after hint", err: fmt.Errorf("wrapped: %w", throttleError{after: time.Second}), wantRetryable: true, wantThrottle: true},
+		{name: "file not found", err: fmt.Errorf("opening export: %w", os.ErrNotExist), wantNotFound: true},
+		{name: "registered classifier", err: fmt.Errorf("wrapped: %w", errQuotaExceeded), wantRetryable: true, wantThrottle: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRetryable, grab.IsRetryable(tt.err), "IsRetryable")
+			assert.Equal(t, tt.wantNotFound, grab.IsNotFound(tt.err), "IsNotFound")
+			assert.Equal(t, tt.wantThrottle, grab.IsThrottle(tt.err), "IsThrottle")
+		})
+	}
+}
+
+func TestRetryNotFound(t *testing.T) {
+	var calls int
+	_, err := grab.Retry(context.Background(), grab.RetryPolicy{}, func(ctx context.Context) (int, error) {
+		calls++
+		return 0, &grab.HTTPStatusError{StatusCode: http.StatusNotFound}
+	})
+	assert.True(t, grab.IsNotFound(err))
+	assert.Equal(t, 1, calls)
+}
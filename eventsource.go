@@ -0,0 +1,139 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Apply folds a slice of events into a state, in order, to rebuild a read model from an event log.
+// It is Reduce with the argument order of event sourcing: the starting state, then the events.
+//
+// Parameters:
+//   - state: The starting state, such as an empty read model or a snapshot.
+//   - events: The events to apply, oldest first.
+//   - apply: A function that returns the state after applying one event. It should not modify its input state
+//     if the caller still needs it.
+//
+// Returns:
+//   - S: The state after applying every event.
+//
+// Example:
+//
+//	grants := Apply(map[string]Grant{}, auditEvents, func(s map[string]Grant, e AuditEvent) map[string]Grant {
+//	    switch e.Type {
+//	    case "grant.activated":
+//	        s[e.GrantID] = e.Grant
+//	    case "grant.expired":
+//	        delete(s, e.GrantID)
+//	    }
+//	    return s
+//	})
+func Apply[S any, E any](state S, events []E, apply func(S, E) S) S {
+	return Reduce(events, state, apply)
+}
+
+// ProjectionCheckpoint is the saved progress of a Projector.
+type ProjectionCheckpoint[S any] struct {
+	// State is the state after applying every event up to and including Position.
+	State S
+	// Position identifies the last event applied, as returned by Projector.Position.
+	Position string
+}
+
+// Projector builds a read model by folding a sequence of events into a state, saving its progress
+// to a KVStore so that it can resume where it left off rather than replaying every event.
+//
+// Example:
+//
+//	projector := Projector[map[string]Grant, AuditEvent]{
+//	    Name:     "active-grants",
+//	    Initial:  map[string]Grant{},
+//	    Apply:    applyGrantEvent,
+//	    Position: func(e AuditEvent) string { return e.ID },
+//	    Store:    checkpointStore,
+//	}
+//
+//	grants, err := projector.Run(ctx, func(ctx context.Context, after string) iter.Seq2[AuditEvent, error] {
+//	    return auditLog.EventsAfter(ctx, after)
+//	})
+type Projector[S any, E any] struct {
+	// Name identifies the projection's checkpoint in the Store.
+	Name string
+	// Initial is the state to start from when there is no checkpoint.
+	Initial S
+	// Apply returns the state after applying one event.
+	Apply func(S, E) S
+	// Position returns the position of an event in the event log, such as its ID or sequence number.
+	// The source is asked for the events after the last checkpointed position.
+	Position func(E) string
+	// Store persists checkpoints. If nil, the projector always starts from Initial and nothing is saved.
+	Store KVStore[string, ProjectionCheckpoint[S]]
+	// CheckpointEvery is the number of events applied between checkpoints. Defaults to 100.
+	// A checkpoint is also saved when the source is exhausted or fails.
+	CheckpointEvery int
+}
+
+// Run resumes the projection from its last checkpoint, applies every event the source yields, and returns the state.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the source and the Store.
+//   - source: A function returning the events after the given position, oldest first.
+//     The position is empty when there is no checkpoint.
+//
+// Returns:
+//   - S: The state after applying every event.
+//   - error: The first error yielded by the source or returned by the Store. Progress made before
+//     a source error is still checkpointed, so the next Run resumes from the last event applied.
+//
+// Note: The state is checkpointed as it is, so it should not be modified after Apply returns it.
+// For a durable Store, S must be serializable by the store.
+func (p *Projector[S, E]) Run(ctx context.Context, source func(ctx context.Context, after string) iter.Seq2[E, error]) (S, error) {
+	every := p.CheckpointEvery
+	if every <= 0 {
+		every = 100
+	}
+
+	checkpoint, err := p.load(ctx)
+	if err != nil {
+		return checkpoint.State, err
+	}
+
+	unsaved := 0
+	for event, err := range source(ctx, checkpoint.Position) {
+		if err != nil {
+			return checkpoint.State, errors.Join(err, p.save(ctx, checkpoint, unsaved))
+		}
+		checkpoint.State = p.Apply(checkpoint.State, event)
+		checkpoint.Position = p.Position(event)
+		unsaved++
+		if unsaved >= every {
+			if err := p.save(ctx, checkpoint, unsaved); err != nil {
+				return checkpoint.State, err
+			}
+			unsaved = 0
+		}
+	}
+	return checkpoint.State, p.save(ctx, checkpoint, unsaved)
+}
+
+// load returns the last checkpoint, or a checkpoint of the initial state if there is none.
+func (p *Projector[S, E]) load(ctx context.Context) (ProjectionCheckpoint[S], error) {
+	initial := ProjectionCheckpoint[S]{State: p.Initial}
+	if p.Store == nil {
+		return initial, nil
+	}
+	checkpoint, ok, err := p.Store.Get(ctx, p.Name)
+	if err != nil || !ok {
+		return initial, err
+	}
+	return checkpoint, nil
+}
+
+// save persists the checkpoint if a Store is set and events have been applied since the last save.
+func (p *Projector[S, E]) save(ctx context.Context, checkpoint ProjectionCheckpoint[S], unsaved int) error {
+	if p.Store == nil || unsaved == 0 {
+		return nil
+	}
+	return p.Store.Put(ctx, p.Name, checkpoint)
+}
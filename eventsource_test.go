@@ -0,0 +1,118 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type ledgerEvent struct {
+	Seq    int
+	Amount int
+}
+
+func applyBalance(balance int, e ledgerEvent) int {
+	return balance + e.Amount
+}
+
+// eventLog returns a source which yields the events after a position, failing after 'failAfter' events if it is positive.
+func eventLog(events []ledgerEvent, failAfter int, errFail error, afters *[]string) func(ctx context.Context, after string) iter.Seq2[ledgerEvent, error] {
+	return func(ctx context.Context, after string) iter.Seq2[ledgerEvent, error] {
+		*afters = append(*afters, after)
+		start := 0
+		if after != "" {
+			start, _ = strconv.Atoi(after)
+		}
+		return func(yield func(ledgerEvent, error) bool) {
+			for i, e := range grab.Filter(events, func(e ledgerEvent) bool { return e.Seq > start }) {
+				if failAfter > 0 && i == failAfter {
+					yield(ledgerEvent{}, errFail)
+					return
+				}
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	events := []ledgerEvent{{Seq: 1, Amount: 10}, {Seq: 2, Amount: -3}}
+	assert.Equal(t, 12, grab.Apply(5, events, applyBalance))
+	assert.Equal(t, 5, grab.Apply(5, nil, applyBalance))
+}
+
+func TestProjector(t *testing.T) {
+	ctx := context.Background()
+	errSource := errors.New("source failed")
+	events := []ledgerEvent{{Seq: 1, Amount: 10}, {Seq: 2, Amount: 20}, {Seq: 3, Amount: 30}, {Seq: 4, Amount: 40}}
+	store := grab.NewMemoryKVStore[string, grab.ProjectionCheckpoint[int]]()
+
+	projector := grab.Projector[int, ledgerEvent]{
+		Name:            "balance",
+		Apply:           applyBalance,
+		Position:        func(e ledgerEvent) string { return strconv.Itoa(e.Seq) },
+		Store:           store,
+		CheckpointEvery: 2,
+	}
+
+	var afters []string
+
+	// the source fails after three events, and the progress is checkpointed
+	balance, err := projector.Run(ctx, eventLog(events, 3, errSource, &afters))
+	assert.ErrorIs(t, err, errSource)
+	assert.Equal(t, 60, balance)
+
+	checkpoint, ok, _ := store.Get(ctx, "balance")
+	assert.True(t, ok)
+	assert.Equal(t, grab.ProjectionCheckpoint[int]{State: 60, Position: "3"}, checkpoint)
+
+	// the next run resumes after the last event applied
+	balance, err = projector.Run(ctx, eventLog(events, 0, nil, &afters))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, balance)
+	assert.Equal(t, []string{"", "3"}, afters)
+
+	// a run with no new events returns the checkpointed state
+	balance, err = projector.Run(ctx, eventLog(events, 0, nil, &afters))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, balance)
+	assert.Equal(t, []string{"", "3", "4"}, afters)
+}
+
+func TestProjectorWithoutStore(t *testing.T) {
+	events := []ledgerEvent{{Seq: 1, Amount: 10}, {Seq: 2, Amount: 20}}
+	projector := grab.Projector[int, ledgerEvent]{
+		Initial:  5,
+		Apply:    applyBalance,
+		Position: func(e ledgerEvent) string { return strconv.Itoa(e.Seq) },
+	}
+
+	var afters []string
+	for range 2 {
+		balance, err := projector.Run(context.Background(), eventLog(events, 0, nil, &afters))
+		assert.NoError(t, err)
+		assert.Equal(t, 35, balance)
+	}
+	assert.Equal(t, []string{"", ""}, afters)
+}
+
+func TestProjectorStoreError(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	projector := grab.Projector[int, ledgerEvent]{
+		Apply:    applyBalance,
+		Position: func(e ledgerEvent) string { return strconv.Itoa(e.Seq) },
+		Store:    failingStore[string, grab.ProjectionCheckpoint[int]]{err: errStore},
+	}
+
+	var afters []string
+	_, err := projector.Run(context.Background(), eventLog(nil, 0, nil, &afters))
+	assert.ErrorIs(t, err, errStore)
+	assert.Empty(t, afters, "the source should not be read if the checkpoint can't be loaded")
+}
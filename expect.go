@@ -0,0 +1,77 @@
+package grab
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrExpectation is wrapped by the errors returned by the Expect helpers.
+var ErrExpectation = errors.New("expectation failed")
+
+// ExpectEqual returns an error if 'got' is not equal to 'want'. Unlike a test assertion, it returns an error
+// rather than failing a test, so it can check invariants in production code.
+//
+// Parameters:
+//   - name: A name for the value being checked, used in the error message.
+//   - got: The actual value.
+//   - want: The expected value.
+//
+// Returns:
+//   - error: An error wrapping ErrExpectation if the values differ, otherwise nil.
+//
+// Example:
+//
+//	if err := ExpectEqual("account ID", grant.AccountID, target.AccountID); err != nil {
+//	    return fmt.Errorf("refusing to provision grant: %w", err)
+//	}
+func ExpectEqual[T comparable](name string, got, want T) error {
+	if got != want {
+		return fmt.Errorf("%w: %s: expected %v, got %v", ErrExpectation, name, want, got)
+	}
+	return nil
+}
+
+// ExpectNonZero returns an error if 'v' is the zero value for its type, such as an empty string or a nil pointer.
+//
+// Parameters:
+//   - name: A name for the value being checked, used in the error message.
+//   - v: The value to check.
+//
+// Returns:
+//   - error: An error wrapping ErrExpectation if 'v' is the zero value, otherwise nil.
+//
+// Example:
+//
+//	err := errors.Join(
+//	    ExpectNonZero("user ID", req.UserID),
+//	    ExpectNonZero("role", req.Role),
+//	)
+func ExpectNonZero[T comparable](name string, v T) error {
+	if IsZero(v) {
+		return fmt.Errorf("%w: %s: expected a non-zero value", ErrExpectation, name)
+	}
+	return nil
+}
+
+// ExpectLen returns an error if the slice doesn't have exactly 'n' items.
+//
+// Parameters:
+//   - name: A name for the slice being checked, used in the error message.
+//   - items: The slice to check.
+//   - n: The expected number of items.
+//
+// Returns:
+//   - error: An error wrapping ErrExpectation if the slice has a different length, otherwise nil.
+//
+// Example:
+//
+//	// a lookup by unique ID should match exactly one user
+//	if err := ExpectLen("users matching "+id, users, 1); err != nil {
+//	    return User{}, err
+//	}
+func ExpectLen[T any](name string, items []T, n int) error {
+	if len(items) != n {
+		return fmt.Errorf("%w: %s: expected %d items, got %d", ErrExpectation, name, n, len(items))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package grab_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpect(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr string
+	}{
+		{name: "equal", err: grab.ExpectEqual("region", "us-east-1", "us-east-1")},
+		{name: "not equal", err: grab.ExpectEqual("region", "eu-west-1", "us-east-1"), wantErr: "expectation failed: region: expected us-east-1, got eu-west-1"},
+		{name: "non-zero", err: grab.ExpectNonZero("user ID", "usr_123")},
+		{name: "zero string", err: grab.ExpectNonZero("user ID", ""), wantErr: "expectation failed: user ID: expected a non-zero value"},
+		{name: "nil pointer", err: grab.ExpectNonZero[*int]("count", nil), wantErr: "expectation failed: count: expected a non-zero value"},
+		{name: "length matches", err: grab.ExpectLen("users", []string{"alice"}, 1)},
+		{name: "length differs", err: grab.ExpectLen("users", []string{"alice", "bob"}, 1), wantErr: "expectation failed: users: expected 1 items, got 2"},
+		{name: "nil slice", err: grab.ExpectLen[string]("users", nil, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == "" {
+				assert.NoError(t, tt.err)
+				return
+			}
+			assert.EqualError(t, tt.err, tt.wantErr)
+			assert.ErrorIs(t, tt.err, grab.ErrExpectation)
+		})
+	}
+}
+
+func TestExpectJoined(t *testing.T) {
+	err := errors.Join(
+		grab.ExpectNonZero("user ID", ""),
+		grab.ExpectNonZero("role", "admin"),
+		grab.ExpectLen("accounts", []string{}, 1),
+	)
+	assert.EqualError(t, err, "expectation failed: user ID: expected a non-zero value\nexpectation failed: accounts: expected 1 items, got 0")
+}
@@ -0,0 +1,130 @@
+package grab
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Expiring holds a value which expires, such as a session token or temporary credentials,
+// and refreshes it before it expires. It is safe for concurrent use.
+//
+// Example:
+// creds := NewExpiring[Credentials](5*time.Minute, WithJitter(0.2))
+//
+//	fetchPage := func(ctx context.Context, token *string) ([]User, *string, error) {
+//	    c, err := creds.Get(ctx, assumeRole)
+//	    if err != nil {
+//	        return nil, nil, err
+//	    }
+//	    return listUsers(ctx, c, token)
+//	}
+type Expiring[T any] struct {
+	refreshBefore time.Duration
+	cfg           Config
+
+	mu        sync.Mutex
+	rng       *rand.Rand
+	value     T
+	expiry    time.Time
+	refreshAt time.Time
+	hasValue  bool
+	inflight  *expiringCall[T]
+}
+
+type expiringCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewExpiring creates an Expiring which holds no value.
+//
+// Parameters:
+//   - refreshBefore: How long before the value expires it is refreshed in the background.
+//   - opts: Optional settings. WithJitter randomly varies 'refreshBefore' so that many processes don't refresh
+//     at the same moment, WithRand sets the source of randomness for the jitter, and WithClock sets the Clock used to tell the time.
+//
+// Returns:
+//   - *Expiring[T]: A new Expiring with no value.
+func NewExpiring[T any](refreshBefore time.Duration, opts ...Option[Config]) *Expiring[T] {
+	cfg := newConfig(opts)
+	return &Expiring[T]{refreshBefore: refreshBefore, cfg: cfg, rng: cfg.randOrDefault()}
+}
+
+// Get returns the value, calling 'refresh' to obtain a new value and its expiry time when needed.
+//
+// If there is no value, or it has expired, Get waits for 'refresh' to complete. If the value is within
+// 'refreshBefore' of expiring, Get returns it immediately and refreshes it in the background.
+// Only one call to 'refresh' is made at a time; concurrent callers share its result.
+//
+// Parameters:
+//   - ctx: A context.Context. If the context is cancelled while waiting for a refresh, Get returns the context error.
+//   - refresh: A function returning a new value and the time it expires.
+//
+// Returns:
+//   - T: The current value.
+//   - error: The error returned by 'refresh', if the value had to be refreshed before it could be returned.
+//
+// Note: 'refresh' is called with a context which is not cancelled when 'ctx' is, because its result is shared
+// by every caller waiting for it. Each caller stops waiting only when its own context is cancelled.
+// If a background refresh fails, the current value is kept and the refresh is retried on the next call to Get.
+func (e *Expiring[T]) Get(ctx context.Context, refresh func(ctx context.Context) (T, time.Time, error)) (T, error) {
+	e.mu.Lock()
+	now := e.cfg.Clock.Now()
+	if e.hasValue && now.Before(e.expiry) {
+		value := e.value
+		if !now.Before(e.refreshAt) && e.inflight == nil {
+			e.startRefresh(context.WithoutCancel(ctx), refresh)
+		}
+		e.mu.Unlock()
+		return value, nil
+	}
+
+	call := e.inflight
+	if call == nil {
+		// the refresh is shared with concurrent callers, so it must not be cancelled when this caller's context is
+		call = e.startRefresh(context.WithoutCancel(ctx), refresh)
+	}
+	e.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// startRefresh calls 'refresh' in a new goroutine. It must be called with the lock held.
+func (e *Expiring[T]) startRefresh(ctx context.Context, refresh func(ctx context.Context) (T, time.Time, error)) *expiringCall[T] {
+	call := &expiringCall[T]{done: make(chan struct{})}
+	e.inflight = call
+
+	go func() {
+		defer close(call.done)
+
+		var (
+			value  T
+			expiry time.Time
+		)
+		// a panic is returned to the callers as a *PanicError, rather than crashing the process
+		err := callRecovered(ctx, func(ctx context.Context) error {
+			var err error
+			value, expiry, err = refresh(ctx)
+			return err
+		}, nil)
+		call.value, call.err = value, err
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.inflight = nil
+		if err == nil {
+			e.value, e.expiry, e.hasValue = value, expiry, true
+			e.refreshAt = expiry.Add(-e.cfg.jittered(e.refreshBefore, e.rng))
+		}
+	}()
+	return call
+}
@@ -0,0 +1,140 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiring(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	token := grab.NewExpiring[string](5*time.Minute, grab.WithClock(clock))
+
+	var calls int32
+	refreshed := make(chan struct{}, 10)
+	refresh := func(ctx context.Context) (string, time.Time, error) {
+		n := atomic.AddInt32(&calls, 1)
+		defer func() { refreshed <- struct{}{} }()
+		return []string{"", "first", "second", "third"}[n], clock.Now().Add(time.Hour), nil
+	}
+
+	got, err := token.Get(context.Background(), refresh)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got)
+	receive(t, refreshed)
+
+	// well before expiry, the cached value is returned
+	clock.Advance(30 * time.Minute)
+	got, err = token.Get(context.Background(), refresh)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// within the refresh window, the cached value is returned and refreshed in the background
+	clock.Advance(26 * time.Minute)
+	got, err = token.Get(context.Background(), refresh)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", got)
+	receive(t, refreshed)
+	assert.Eventually(t, func() bool {
+		got, _ := token.Get(context.Background(), refresh)
+		return got == "second"
+	}, time.Second, time.Millisecond)
+
+	// after expiry, Get waits for the refresh
+	clock.Advance(2 * time.Hour)
+	got, err = token.Get(context.Background(), refresh)
+	assert.NoError(t, err)
+	assert.Equal(t, "third", got)
+}
+
+func TestExpiringSingleFlight(t *testing.T) {
+	token := grab.NewExpiring[int](time.Minute)
+	var calls int32
+	release := make(chan struct{})
+	refresh := func(ctx context.Context) (int, time.Time, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, time.Now().Add(time.Hour), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = token.Get(context.Background(), refresh)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+}
+
+func TestExpiringFirstCallerCancelled(t *testing.T) {
+	token := grab.NewExpiring[string](time.Minute)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	refresh := func(ctx context.Context) (string, time.Time, error) {
+		close(started)
+		select {
+		case <-release:
+			return "token", time.Now().Add(time.Hour), nil
+		case <-ctx.Done():
+			return "", time.Time{}, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := token.Get(ctx, refresh)
+		first <- err
+	}()
+	receive(t, started)
+
+	second := make(chan string, 1)
+	go func() {
+		got, err := token.Get(context.Background(), refresh)
+		assert.NoError(t, err)
+		second <- got
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// the first caller stops waiting, but the shared refresh carries on for the second caller
+	cancel()
+	assert.ErrorIs(t, receive(t, first), context.Canceled)
+	close(release)
+	assert.Equal(t, "token", receive(t, second))
+}
+
+func TestExpiringRefreshError(t *testing.T) {
+	token := grab.NewExpiring[int](time.Minute)
+
+	_, err := token.Get(context.Background(), func(ctx context.Context) (int, time.Time, error) {
+		return 0, time.Time{}, errors.New("mock")
+	})
+	assert.EqualError(t, err, "mock")
+
+	_, err = token.Get(context.Background(), func(ctx context.Context) (int, time.Time, error) {
+		panic("boom")
+	})
+	var panicErr *grab.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+
+	got, err := token.Get(context.Background(), func(ctx context.Context) (int, time.Time, error) {
+		return 1, time.Now().Add(time.Hour), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
@@ -0,0 +1,220 @@
+package grab
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// ExternalSortOptions configures SortExternal.
+type ExternalSortOptions struct {
+	// RunSize is the maximum number of items held in memory and written to each temporary file.
+	// Defaults to 100,000.
+	RunSize int
+	// TempDir is the directory temporary files are created in. Defaults to os.TempDir().
+	TempDir string
+}
+
+// SortExternal sorts a sequence which may be too large to fit in memory. Items are read in runs of
+// at most RunSize, each run is sorted and spilled to a temporary newline-delimited JSON file,
+// and the runs are then lazily merged.
+// It is a generic function that works with any type 'T' which round-trips through encoding/json.
+//
+// Parameters:
+//   - ctx: A context.Context used to stop sorting early.
+//   - seq: The sequence of items to sort.
+//   - less: A function reporting whether 'a' sorts before 'b'.
+//   - opts: Options controlling the run size and temporary file location.
+//
+// Returns:
+//   - iter.Seq2[T, error]: The sorted items. Nothing is read from 'seq' until the result is iterated.
+//     If an error occurs, it is yielded with the zero value of 'T' and iteration stops.
+//     Temporary files are removed when iteration finishes or is stopped early.
+//
+// Example:
+//
+//	sorted := SortExternal(ctx, exportRows, func(a, b Row) bool { return a.UserID < b.UserID }, ExternalSortOptions{})
+//
+//	for row, err := range sorted {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    write(row)
+//	}
+//
+// Note: Sorting is stable, and a sequence which fits in a single run is sorted in memory without temporary files.
+func SortExternal[T any](ctx context.Context, seq iter.Seq[T], less func(a, b T) bool, opts ExternalSortOptions) iter.Seq2[T, error] {
+	if opts.RunSize <= 0 {
+		opts.RunSize = 100_000
+	}
+	cmp := func(a, b T) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		var runs []*os.File
+		defer func() {
+			for _, f := range runs {
+				f.Close()
+				os.Remove(f.Name())
+			}
+		}()
+
+		var buf []T
+		for item := range seq {
+			buf = append(buf, item)
+			if len(buf) < opts.RunSize {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			f, err := spillRun(buf, cmp, opts.TempDir)
+			if f != nil {
+				runs = append(runs, f)
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			buf = buf[:0]
+		}
+
+		slices.SortStableFunc(buf, cmp)
+
+		if len(runs) == 0 {
+			for _, item := range buf {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		// merge the spilled runs with the final in-memory run
+		h := &runHeap[T]{cmp: cmp}
+		for i, f := range runs {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				yield(zero, err)
+				return
+			}
+			c := &runCursor[T]{order: i, dec: json.NewDecoder(bufio.NewReader(f))}
+			if err := c.next(); err != nil {
+				yield(zero, err)
+				return
+			}
+			if c.ok {
+				h.cursors = append(h.cursors, c)
+			}
+		}
+		if len(buf) > 0 {
+			c := &runCursor[T]{order: len(runs), mem: buf}
+			_ = c.next()
+			h.cursors = append(h.cursors, c)
+		}
+		heap.Init(h)
+
+		for n := 0; h.Len() > 0; n++ {
+			if n%1000 == 0 {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+			}
+			c := h.cursors[0]
+			if !yield(c.head, nil) {
+				return
+			}
+			if err := c.next(); err != nil {
+				yield(zero, err)
+				return
+			}
+			if c.ok {
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// spillRun sorts the items and writes them to a temporary file as newline-delimited JSON.
+func spillRun[T any](items []T, cmp func(a, b T) int, dir string) (*os.File, error) {
+	slices.SortStableFunc(items, cmp)
+	f, err := os.CreateTemp(dir, "grab-sort-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return f, err
+		}
+	}
+	return f, w.Flush()
+}
+
+// runCursor reads the sorted items of a single run, either from a file or from memory.
+type runCursor[T any] struct {
+	order int // runs are ordered by creation so that merging is stable
+	dec   *json.Decoder
+	mem   []T
+	head  T
+	ok    bool
+}
+
+func (c *runCursor[T]) next() error {
+	if c.dec == nil {
+		c.ok = len(c.mem) > 0
+		if c.ok {
+			c.head, c.mem = c.mem[0], c.mem[1:]
+		}
+		return nil
+	}
+	var v T
+	err := c.dec.Decode(&v)
+	if errors.Is(err, io.EOF) {
+		c.ok = false
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.head, c.ok = v, true
+	return nil
+}
+
+type runHeap[T any] struct {
+	cursors []*runCursor[T]
+	cmp     func(a, b T) int
+}
+
+func (h *runHeap[T]) Len() int { return len(h.cursors) }
+func (h *runHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.cursors[i].head, h.cursors[j].head); c != 0 {
+		return c < 0
+	}
+	return h.cursors[i].order < h.cursors[j].order
+}
+func (h *runHeap[T]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+func (h *runHeap[T]) Push(x any)    { h.cursors = append(h.cursors, x.(*runCursor[T])) }
+func (h *runHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
@@ -0,0 +1,84 @@
+package grab_test
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortExternal(t *testing.T) {
+	type row struct {
+		Key   int
+		Order int
+	}
+	r := rand.New(rand.NewSource(1))
+	var rows []row
+	for i := 0; i < 1000; i++ {
+		rows = append(rows, row{Key: r.Intn(50), Order: i})
+	}
+	want := slices.Clone(rows)
+	slices.SortStableFunc(want, func(a, b row) int { return a.Key - b.Key })
+	less := func(a, b row) bool { return a.Key < b.Key }
+
+	tests := []struct {
+		name    string
+		runSize int
+	}{
+		{
+			name:    "in memory",
+			runSize: 10000,
+		},
+		{
+			name:    "spilled runs",
+			runSize: 64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var got []row
+			for item, err := range grab.SortExternal(context.Background(), slices.Values(rows), less, grab.ExternalSortOptions{RunSize: tt.runSize, TempDir: dir}) {
+				assert.NoError(t, err)
+				got = append(got, item)
+			}
+			assert.Equal(t, want, got)
+
+			files, err := filepath.Glob(filepath.Join(dir, "*"))
+			assert.NoError(t, err)
+			assert.Empty(t, files, "temporary files are removed")
+		})
+	}
+}
+
+func TestSortExternalStopEarly(t *testing.T) {
+	dir := t.TempDir()
+	items := []int{5, 4, 3, 2, 1, 0}
+	var got []int
+	for item, err := range grab.SortExternal(context.Background(), slices.Values(items), func(a, b int) bool { return a < b }, grab.ExternalSortOptions{RunSize: 2, TempDir: dir}) {
+		assert.NoError(t, err)
+		got = append(got, item)
+		if len(got) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2}, got)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSortExternalCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	items := []int{3, 2, 1}
+	for _, err := range grab.SortExternal(ctx, slices.Values(items), func(a, b int) bool { return a < b }, grab.ExternalSortOptions{RunSize: 1, TempDir: t.TempDir()}) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
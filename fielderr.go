@@ -0,0 +1,101 @@
+package grab
+
+import "fmt"
+
+// Fields are structured key/value pairs attached to an error by FieldErrorf.
+type Fields map[string]any
+
+type fieldError struct {
+	err    error
+	fields Fields
+}
+
+func (e *fieldError) Error() string { return e.err.Error() }
+func (e *fieldError) Unwrap() error { return e.err }
+
+// FieldErrorf formats an error as in fmt.Errorf, including support for wrapping errors with %w,
+// and attaches structured fields which can be retrieved with FieldFromErr or ErrFields.
+// The fields are not included in the error message.
+//
+// Parameters:
+//   - fields: The fields to attach to the error.
+//   - format: A format string, as in fmt.Errorf.
+//   - args: The arguments for the format string.
+//
+// Returns:
+//   - error: An error with the formatted message and the attached fields.
+//
+// Example:
+// err = FieldErrorf(Fields{"page": page, "token": token}, "fetching page: %w", err)
+//
+// Note: This function lets pagination and fan-out helpers attach context such as page numbers and shard IDs,
+// which logging middleware can extract without parsing error messages.
+func FieldErrorf(fields Fields, format string, args ...any) error {
+	return &fieldError{err: fmt.Errorf(format, args...), fields: fields}
+}
+
+// FieldFromErr returns the value of a field attached to an error, or to any error it wraps, by FieldErrorf.
+// If several errors in the chain have the field, the value from the outermost error is returned.
+//
+// Parameters:
+//   - err: The error to inspect.
+//   - key: The name of the field.
+//
+// Returns:
+//   - T: The value of the field.
+//   - bool: False if no error in the chain has the field, or if its value is not of type 'T'.
+//
+// Example:
+// page, ok := FieldFromErr[int](err, "page")
+func FieldFromErr[T any](err error, key string) (T, bool) {
+	var zero T
+	v, ok := ErrFields(err)[key]
+	if !ok {
+		return zero, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
+
+// ErrFields returns all fields attached to an error and the errors it wraps by FieldErrorf,
+// with fields on outer errors taking precedence. It returns nil if there are no fields.
+//
+// Example:
+// logger.Error("sync failed", "error", err, "fields", ErrFields(err))
+func ErrFields(err error) Fields {
+	var result Fields
+	walkErrors(err, func(err error) {
+		fe, ok := err.(*fieldError)
+		if !ok {
+			return
+		}
+		for k, v := range fe.fields {
+			if _, exists := result[k]; exists {
+				continue
+			}
+			if result == nil {
+				result = make(Fields)
+			}
+			result[k] = v
+		}
+	})
+	return result
+}
+
+// walkErrors calls 'fn' for the error and every error it wraps, depth first, outermost first.
+func walkErrors(err error, fn func(err error)) {
+	for err != nil {
+		fn(err)
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walkErrors(inner, fn)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
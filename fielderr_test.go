@@ -0,0 +1,81 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldErrorf(t *testing.T) {
+	errMock := errors.New("mock")
+	inner := grab.FieldErrorf(grab.Fields{"page": 2, "token": "abc"}, "fetching page: %w", errMock)
+	outer := grab.FieldErrorf(grab.Fields{"shard": "us-east-1", "token": "def"}, "syncing shard: %w", inner)
+
+	assert.EqualError(t, outer, "syncing shard: fetching page: mock")
+	assert.ErrorIs(t, outer, errMock)
+
+	page, ok := grab.FieldFromErr[int](outer, "page")
+	assert.True(t, ok)
+	assert.Equal(t, 2, page)
+
+	token, ok := grab.FieldFromErr[string](outer, "token")
+	assert.True(t, ok)
+	assert.Equal(t, "def", token, "outer fields should take precedence")
+
+	_, ok = grab.FieldFromErr[string](outer, "page")
+	assert.False(t, ok, "field of a different type should not be returned")
+
+	_, ok = grab.FieldFromErr[int](errMock, "page")
+	assert.False(t, ok)
+
+	assert.Equal(t, grab.Fields{"page": 2, "shard": "us-east-1", "token": "def"}, grab.ErrFields(outer))
+}
+
+func TestErrFields(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want grab.Fields
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "no fields", err: errors.New("mock"), want: nil},
+		{
+			name: "wrapped with fmt",
+			err:  fmt.Errorf("outer: %w", grab.FieldErrorf(grab.Fields{"page": 1}, "inner")),
+			want: grab.Fields{"page": 1},
+		},
+		{
+			name: "joined",
+			err: errors.Join(
+				grab.FieldErrorf(grab.Fields{"shard": 1}, "first"),
+				grab.FieldErrorf(grab.Fields{"page": 3}, "second"),
+			),
+			want: grab.Fields{"shard": 1, "page": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.ErrFields(tt.err))
+		})
+	}
+}
+
+func TestAllPagesWithHooksErrorPageField(t *testing.T) {
+	errMock := errors.New("mock")
+	_, err := grab.AllPagesWithHooks(context.Background(), nil, "", func(ctx context.Context, token *int) ([]int, *int, error) {
+		if grab.Value(token) == 2 {
+			return nil, nil, errMock
+		}
+		return []int{1}, grab.Ptr(grab.Value(token) + 1), nil
+	})
+	assert.EqualError(t, err, "mock")
+	assert.ErrorIs(t, err, errMock)
+
+	page, ok := grab.FieldFromErr[int](err, "page")
+	assert.True(t, ok)
+	assert.Equal(t, 2, page)
+}
@@ -0,0 +1,119 @@
+package grab
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"unsafe"
+)
+
+// Flags is a compact set of bit flags of an integer type, such as a permission mask.
+// Each flag is expected to be a single bit, such as the values of a `1 << iota` enum.
+// Flags is a value type: methods which change the set return a new set, leaving the original unchanged.
+// The zero value is an empty set.
+//
+// Example:
+//
+//	type Permission uint8
+//
+//	const (
+//	    Read Permission = 1 << iota
+//	    Write
+//	    Admin
+//	)
+//
+//	perms := NewFlags(Read, Write)
+//	perms.Has(Write) // true
+//	perms = perms.Clear(Write).Set(Admin)
+//	perms.String() // "Read|Admin" if Permission has a String method, otherwise "1|4"
+type Flags[T Integer] struct {
+	bits T
+}
+
+// NewFlags creates a set containing the flags.
+func NewFlags[T Integer](flags ...T) Flags[T] {
+	return Flags[T]{}.Set(flags...)
+}
+
+// Bits returns the set as a bit mask.
+func (f Flags[T]) Bits() T {
+	return f.bits
+}
+
+// Set returns a copy of the set with the flags added.
+func (f Flags[T]) Set(flags ...T) Flags[T] {
+	for _, flag := range flags {
+		f.bits |= flag
+	}
+	return f
+}
+
+// Clear returns a copy of the set with the flags removed.
+func (f Flags[T]) Clear(flags ...T) Flags[T] {
+	for _, flag := range flags {
+		f.bits &^= flag
+	}
+	return f
+}
+
+// Has reports whether every bit of the flag is in the set.
+func (f Flags[T]) Has(flag T) bool {
+	return f.bits&flag == flag
+}
+
+// HasAny reports whether any of the flags are in the set.
+func (f Flags[T]) HasAny(flags ...T) bool {
+	for _, flag := range flags {
+		if f.bits&flag != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns a set containing the flags in either set.
+func (f Flags[T]) Union(other Flags[T]) Flags[T] {
+	return Flags[T]{bits: f.bits | other.bits}
+}
+
+// Intersect returns a set containing the flags in both sets.
+func (f Flags[T]) Intersect(other Flags[T]) Flags[T] {
+	return Flags[T]{bits: f.bits & other.bits}
+}
+
+// IsEmpty reports whether the set contains no flags.
+func (f Flags[T]) IsEmpty() bool {
+	return f.bits == 0
+}
+
+// Len returns the number of bits set.
+func (f Flags[T]) Len() int {
+	return bits.OnesCount64(f.uint64())
+}
+
+// String renders the set flags from the lowest bit to the highest, separated by "|".
+// Each flag is formatted with fmt.Sprint, so flag types with a String method are rendered by name.
+// An empty set is rendered as "0".
+func (f Flags[T]) String() string {
+	if f.bits == 0 {
+		return "0"
+	}
+	var parts []string
+	remaining := f.uint64()
+	for remaining != 0 {
+		bit := remaining & -remaining
+		parts = append(parts, fmt.Sprint(T(bit)))
+		remaining &^= bit
+	}
+	return strings.Join(parts, "|")
+}
+
+// uint64 returns the bits of the set, masked to the width of T so that a signed T with its top bit set
+// isn't sign-extended into the upper bits.
+func (f Flags[T]) uint64() uint64 {
+	width := 8 * unsafe.Sizeof(f.bits)
+	if width >= 64 {
+		return uint64(f.bits)
+	}
+	return uint64(f.bits) & (1<<width - 1)
+}
@@ -0,0 +1,78 @@
+package grab_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type permission uint8
+
+const (
+	permRead permission = 1 << iota
+	permWrite
+	permAdmin
+)
+
+func (p permission) String() string {
+	switch p {
+	case permRead:
+		return "Read"
+	case permWrite:
+		return "Write"
+	case permAdmin:
+		return "Admin"
+	}
+	return "Unknown"
+}
+
+func TestFlags(t *testing.T) {
+	perms := grab.NewFlags(permRead, permWrite)
+	assert.True(t, perms.Has(permRead))
+	assert.True(t, perms.Has(permRead|permWrite))
+	assert.False(t, perms.Has(permAdmin))
+	assert.True(t, perms.HasAny(permAdmin, permWrite))
+	assert.False(t, perms.HasAny(permAdmin))
+	assert.Equal(t, 2, perms.Len())
+	assert.Equal(t, "Read|Write", perms.String())
+
+	changed := perms.Clear(permWrite).Set(permAdmin)
+	assert.Equal(t, "Read|Admin", changed.String())
+	assert.Equal(t, "Read|Write", perms.String(), "the original set should not be modified")
+	assert.Equal(t, permRead|permAdmin, changed.Bits())
+
+	assert.Equal(t, "Read|Write|Admin", perms.Union(changed).String())
+	assert.Equal(t, "Read", perms.Intersect(changed).String())
+
+	var empty grab.Flags[permission]
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, "0", empty.String())
+}
+
+func TestFlagsWithoutStringer(t *testing.T) {
+	flags := grab.NewFlags[uint16](1, 4, 256)
+	assert.Equal(t, "1|4|256", flags.String())
+}
+
+func TestFlagsSigned(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   grab.Flags[int8]
+		wantLen int
+		want    string
+	}{
+		{name: "top bit", flags: grab.NewFlags[int8](math.MinInt8), wantLen: 1, want: "-128"},
+		{name: "top bit and low bit", flags: grab.NewFlags[int8](1, math.MinInt8), wantLen: 2, want: "1|-128"},
+		{name: "all bits", flags: grab.NewFlags[int8](-1), wantLen: 8, want: "1|2|4|8|16|32|64|-128"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantLen, tt.flags.Len())
+			assert.Equal(t, tt.want, tt.flags.String())
+		})
+	}
+
+	assert.Equal(t, 64, grab.NewFlags[int64](-1).Len())
+}
@@ -0,0 +1,95 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the error returned by a Flaky function when FaultSpec.Err is not set.
+var ErrInjectedFault = errors.New("grab: injected fault")
+
+// FaultSpec describes the faults injected by Flaky.
+// Rates are probabilities between 0 and 1, evaluated independently on each call.
+type FaultSpec struct {
+	// FailFirst makes the first N calls fail with Err, before any rates are applied.
+	FailFirst int
+	// ErrorRate is the probability that a call fails with Err instead of calling the wrapped function.
+	ErrorRate float64
+	// Err is the error returned for injected failures. Defaults to ErrInjectedFault.
+	Err error
+	// LatencyRate is the probability that Latency is added before a call.
+	LatencyRate float64
+	// Latency is the delay added before a call.
+	Latency time.Duration
+	// PanicRate is the probability that a call panics instead of calling the wrapped function.
+	PanicRate float64
+}
+
+// Flaky wraps a function so that it fails, slows down or panics according to a FaultSpec.
+// It is a generic function that works with any result type 'T'.
+//
+// Parameters:
+//   - fn: The function to wrap.
+//   - spec: The faults to inject.
+//   - opts: Optional settings. WithRand sets the source of randomness used to evaluate the rates; provide a
+//     seeded source to make the injected faults deterministic. WithClock sets the Clock used for added latency.
+//
+// Returns:
+//   - func(ctx context.Context) (T, error): A function which injects faults before calling 'fn'.
+//     It is safe for concurrent use.
+//
+// Example:
+//
+//	flaky := Flaky(fetchUsers, FaultSpec{
+//	    FailFirst: 2,
+//	    ErrorRate: 0.1,
+//	}, WithRand(rand.New(rand.NewSource(1))))
+//
+// // the first two calls to flaky fail, and 10% of later calls fail
+//
+// Note: This function is intended for tests, to exercise retry and error handling paths deterministically.
+func Flaky[T any](fn func(ctx context.Context) (T, error), spec FaultSpec, opts ...Option[Config]) func(ctx context.Context) (T, error) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	cfg := newConfig(opts)
+	rng := cfg.randOrDefault()
+	faultErr := spec.Err
+	if faultErr == nil {
+		faultErr = ErrInjectedFault
+	}
+
+	return func(ctx context.Context) (T, error) {
+		var zero T
+
+		// rand.Rand is not safe for concurrent use, so roll all dice for this call under the lock
+		mu.Lock()
+		calls++
+		failFirst := calls <= spec.FailFirst
+		slow := rng.Float64() < spec.LatencyRate
+		shouldPanic := rng.Float64() < spec.PanicRate
+		shouldFail := rng.Float64() < spec.ErrorRate
+		mu.Unlock()
+
+		if failFirst {
+			return zero, faultErr
+		}
+		if slow {
+			select {
+			case <-cfg.Clock.After(spec.Latency):
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			}
+		}
+		if shouldPanic {
+			panic(ErrInjectedFault)
+		}
+		if shouldFail {
+			return zero, faultErr
+		}
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,81 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlaky(t *testing.T) {
+	ok := func(ctx context.Context) (string, error) { return "ok", nil }
+	mockErr := errors.New("mock")
+
+	tests := []struct {
+		name      string
+		spec      grab.FaultSpec
+		wantErrs  []error
+		wantPanic bool
+	}{
+		{
+			name:     "no faults",
+			spec:     grab.FaultSpec{},
+			wantErrs: []error{nil, nil, nil},
+		},
+		{
+			name:     "fail first",
+			spec:     grab.FaultSpec{FailFirst: 2, Err: mockErr},
+			wantErrs: []error{mockErr, mockErr, nil},
+		},
+		{
+			name:     "always fail",
+			spec:     grab.FaultSpec{ErrorRate: 1},
+			wantErrs: []error{grab.ErrInjectedFault, grab.ErrInjectedFault},
+		},
+		{
+			name:     "added latency",
+			spec:     grab.FaultSpec{LatencyRate: 1, Latency: time.Millisecond},
+			wantErrs: []error{nil},
+		},
+		{
+			name:      "always panic",
+			spec:      grab.FaultSpec{PanicRate: 1},
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := grab.Flaky(ok, tt.spec)
+			if tt.wantPanic {
+				assert.Panics(t, func() { _, _ = fn(context.Background()) })
+				return
+			}
+			for _, wantErr := range tt.wantErrs {
+				got, err := fn(context.Background())
+				assert.Equal(t, wantErr, err)
+				if wantErr == nil {
+					assert.Equal(t, "ok", got)
+				}
+			}
+		})
+	}
+}
+
+func TestFlakyDeterministic(t *testing.T) {
+	run := func() []bool {
+		fn := grab.Flaky(func(ctx context.Context) (int, error) { return 1, nil }, grab.FaultSpec{
+			ErrorRate: 0.5,
+		}, grab.WithRand(rand.New(rand.NewSource(42))))
+		var failed []bool
+		for i := 0; i < 20; i++ {
+			_, err := fn(context.Background())
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+	assert.Equal(t, run(), run())
+}
@@ -0,0 +1,99 @@
+package grab
+
+import (
+	"context"
+	"reflect"
+)
+
+// GateMode selects which implementation a Gate runs.
+type GateMode int
+
+const (
+	// GateControl runs only the control (existing) implementation.
+	GateControl GateMode = iota
+	// GateCandidate runs only the candidate (new) implementation.
+	GateCandidate
+	// GateShadow runs both implementations, returns the control result,
+	// and reports any mismatch between the two results.
+	GateShadow
+)
+
+// GateMismatch describes a difference between the control and candidate results of a Gate running in GateShadow mode.
+type GateMismatch[T any] struct {
+	Control      T
+	ControlErr   error
+	Candidate    T
+	CandidateErr error
+}
+
+// Gate chooses between a control and a candidate implementation of an operation based on a flag,
+// to safely migrate from one implementation to another.
+//
+// Example:
+//
+//	gate := Gate[[]User]{
+//	    Mode: func(ctx context.Context) GateMode { return flags.Mode(ctx, "new-user-listing") },
+//	    OnMismatch: func(ctx context.Context, m GateMismatch[[]User]) {
+//	        log.Printf("user listing mismatch: %d vs %d users", len(m.Control), len(m.Candidate))
+//	    },
+//	}
+//
+// users, err := gate.Run(ctx, listUsersV1, listUsersV2)
+type Gate[T any] struct {
+	// Mode returns the mode to run in, usually by consulting a feature flag provider.
+	// If nil, the gate always runs in GateControl mode.
+	Mode func(ctx context.Context) GateMode
+	// Equal compares the control and candidate results in GateShadow mode.
+	// If nil, results are compared with reflect.DeepEqual.
+	Equal func(control, candidate T) bool
+	// OnMismatch is called in GateShadow mode when the results differ, either because one implementation
+	// failed and the other didn't, or because both succeeded and Equal returned false.
+	OnMismatch func(ctx context.Context, mismatch GateMismatch[T])
+}
+
+// Run runs the control or candidate implementation (or both) according to the gate's mode.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the mode provider and the implementations.
+//   - control: The existing implementation.
+//   - candidate: The new implementation.
+//
+// Returns:
+//   - T: The result of the candidate in GateCandidate mode, and of the control otherwise.
+//   - error: The error of the candidate in GateCandidate mode, and of the control otherwise.
+//     In GateShadow mode, errors from the candidate are only reported through OnMismatch.
+func (g *Gate[T]) Run(ctx context.Context, control, candidate func(ctx context.Context) (T, error)) (T, error) {
+	mode := GateControl
+	if g.Mode != nil {
+		mode = g.Mode(ctx)
+	}
+
+	switch mode {
+	case GateCandidate:
+		return candidate(ctx)
+	case GateShadow:
+		result, err := control(ctx)
+		shadow, shadowErr := candidate(ctx)
+		if !g.matches(result, err, shadow, shadowErr) && g.OnMismatch != nil {
+			g.OnMismatch(ctx, GateMismatch[T]{
+				Control:      result,
+				ControlErr:   err,
+				Candidate:    shadow,
+				CandidateErr: shadowErr,
+			})
+		}
+		return result, err
+	default:
+		return control(ctx)
+	}
+}
+
+func (g *Gate[T]) matches(control T, controlErr error, candidate T, candidateErr error) bool {
+	if controlErr != nil || candidateErr != nil {
+		return controlErr != nil && candidateErr != nil
+	}
+	if g.Equal != nil {
+		return g.Equal(control, candidate)
+	}
+	return reflect.DeepEqual(control, candidate)
+}
@@ -0,0 +1,128 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGate(t *testing.T) {
+	mockErr := errors.New("mock")
+
+	tests := []struct {
+		name          string
+		mode          grab.GateMode
+		control       []int
+		candidate     []int
+		candidateErr  error
+		want          []int
+		wantErr       error
+		wantCalls     []string
+		wantMismatch  bool
+		equalUnsorted bool
+	}{
+		{
+			name:      "control",
+			mode:      grab.GateControl,
+			control:   []int{1},
+			want:      []int{1},
+			wantCalls: []string{"control"},
+		},
+		{
+			name:      "candidate",
+			mode:      grab.GateCandidate,
+			candidate: []int{2},
+			want:      []int{2},
+			wantCalls: []string{"candidate"},
+		},
+		{
+			name:      "shadow with matching results",
+			mode:      grab.GateShadow,
+			control:   []int{1, 2},
+			candidate: []int{1, 2},
+			want:      []int{1, 2},
+			wantCalls: []string{"control", "candidate"},
+		},
+		{
+			name:         "shadow with different results",
+			mode:         grab.GateShadow,
+			control:      []int{1, 2},
+			candidate:    []int{2, 1},
+			want:         []int{1, 2},
+			wantCalls:    []string{"control", "candidate"},
+			wantMismatch: true,
+		},
+		{
+			name:          "shadow with custom equality",
+			mode:          grab.GateShadow,
+			control:       []int{1, 2},
+			candidate:     []int{2, 1},
+			want:          []int{1, 2},
+			wantCalls:     []string{"control", "candidate"},
+			equalUnsorted: true,
+		},
+		{
+			name:         "shadow candidate error is not returned",
+			mode:         grab.GateShadow,
+			control:      []int{1},
+			candidateErr: mockErr,
+			want:         []int{1},
+			wantCalls:    []string{"control", "candidate"},
+			wantMismatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			var mismatch *grab.GateMismatch[[]int]
+			gate := grab.Gate[[]int]{
+				Mode: func(ctx context.Context) grab.GateMode { return tt.mode },
+				OnMismatch: func(ctx context.Context, m grab.GateMismatch[[]int]) {
+					mismatch = &m
+				},
+			}
+			if tt.equalUnsorted {
+				gate.Equal = func(control, candidate []int) bool {
+					a, b := slices.Clone(control), slices.Clone(candidate)
+					slices.Sort(a)
+					slices.Sort(b)
+					return slices.Equal(a, b)
+				}
+			}
+
+			got, err := gate.Run(context.Background(), func(ctx context.Context) ([]int, error) {
+				calls = append(calls, "control")
+				return tt.control, nil
+			}, func(ctx context.Context) ([]int, error) {
+				calls = append(calls, "candidate")
+				return tt.candidate, tt.candidateErr
+			})
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantMismatch {
+				assert.Equal(t, &grab.GateMismatch[[]int]{
+					Control:      tt.control,
+					Candidate:    tt.candidate,
+					CandidateErr: tt.candidateErr,
+				}, mismatch)
+			} else {
+				assert.Nil(t, mismatch)
+			}
+		})
+	}
+}
+
+func TestGateDefaultsToControl(t *testing.T) {
+	var gate grab.Gate[string]
+	got, err := gate.Run(context.Background(),
+		func(ctx context.Context) (string, error) { return "control", nil },
+		func(ctx context.Context) (string, error) { return "candidate", nil },
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "control", got)
+}
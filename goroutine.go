@@ -0,0 +1,138 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is the error reported when a goroutine started by Go or GoWithRecover panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("goroutine panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and errors.As can inspect it.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// GoConfig holds the optional settings for Go.
+type GoConfig struct {
+	// OnPanic is called when the goroutine panics. Defaults to logging the panic and its stack trace with slog.
+	OnPanic func(ctx context.Context, err *PanicError)
+	// Errors receives the error returned by the goroutine, or a *PanicError if it panicked.
+	// Nil errors are not sent. The send is abandoned if the context is cancelled first.
+	Errors chan<- error
+	// WaitGroup is incremented when the goroutine starts and decremented when it finishes, if set.
+	WaitGroup *sync.WaitGroup
+}
+
+// WithPanicHandler sets the function called when a goroutine started by Go panics.
+func WithPanicHandler(fn func(ctx context.Context, err *PanicError)) Option[GoConfig] {
+	return WithField(func(c *GoConfig) *func(context.Context, *PanicError) { return &c.OnPanic }, fn)
+}
+
+// WithErrors sets a channel which receives the errors returned by goroutines started by Go,
+// so that several goroutines can report to a shared channel.
+func WithErrors(errs chan<- error) Option[GoConfig] {
+	return WithField(func(c *GoConfig) *chan<- error { return &c.Errors }, errs)
+}
+
+// WithWaitGroup sets a WaitGroup which tracks goroutines started by Go, so the caller can wait for them to finish.
+func WithWaitGroup(wg *sync.WaitGroup) Option[GoConfig] {
+	return WithField(func(c *GoConfig) **sync.WaitGroup { return &c.WaitGroup }, wg)
+}
+
+// Go starts a goroutine which calls 'fn', recovering any panic so that it can't crash the process.
+//
+// Parameters:
+//   - ctx: A context.Context passed to 'fn' and to the panic handler.
+//   - fn: The function to run in the goroutine.
+//   - opts: Optional settings. WithPanicHandler sets how panics are reported, WithErrors sends the result
+//     to a shared error channel, and WithWaitGroup tracks the goroutine in a WaitGroup.
+//
+// Example:
+//
+//	var wg sync.WaitGroup
+//	errs := make(chan error, len(accounts))
+//	for _, account := range accounts {
+//	    Go(ctx, func(ctx context.Context) error {
+//	        return syncAccount(ctx, account)
+//	    }, WithErrors(errs), WithWaitGroup(&wg))
+//	}
+//	wg.Wait()
+//
+// Note: A panic is reported to the panic handler and, if WithErrors is set, sent to the error channel as a *PanicError.
+func Go(ctx context.Context, fn func(ctx context.Context) error, opts ...Option[GoConfig]) {
+	cfg := GoConfig{OnPanic: logPanic}
+	ApplyOptions(&cfg, opts...)
+
+	if cfg.WaitGroup != nil {
+		cfg.WaitGroup.Add(1)
+	}
+	go func() {
+		if cfg.WaitGroup != nil {
+			defer cfg.WaitGroup.Done()
+		}
+		err := callRecovered(ctx, fn, cfg.OnPanic)
+		if err == nil || cfg.Errors == nil {
+			return
+		}
+		select {
+		case cfg.Errors <- err:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// GoWithRecover starts a goroutine which calls 'fn', passing any panic to 'onPanic' instead of crashing the process.
+// It is a shorthand for Go with a function that doesn't return an error.
+//
+// Parameters:
+//   - ctx: A context.Context passed to 'fn' and to 'onPanic'.
+//   - fn: The function to run in the goroutine.
+//   - onPanic: The function called if 'fn' panics. If nil, the panic is logged with slog.
+//
+// Example:
+//
+//	GoWithRecover(ctx, func(ctx context.Context) {
+//	    publishMetrics(ctx)
+//	}, func(ctx context.Context, err *PanicError) {
+//	    sentry.CaptureException(err)
+//	})
+func GoWithRecover(ctx context.Context, fn func(ctx context.Context), onPanic func(ctx context.Context, err *PanicError)) {
+	if onPanic == nil {
+		onPanic = logPanic
+	}
+	Go(ctx, func(ctx context.Context) error {
+		fn(ctx)
+		return nil
+	}, WithPanicHandler(onPanic))
+}
+
+func callRecovered(ctx context.Context, fn func(ctx context.Context) error, onPanic func(ctx context.Context, err *PanicError)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+			if onPanic != nil {
+				onPanic(ctx, panicErr)
+			}
+			err = panicErr
+		}
+	}()
+	return fn(ctx)
+}
+
+func logPanic(ctx context.Context, err *PanicError) {
+	slog.ErrorContext(ctx, "recovered from panic in goroutine", "error", err, "stack", string(err.Stack))
+}
@@ -0,0 +1,84 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGo(t *testing.T) {
+	errMock := errors.New("mock")
+	tests := []struct {
+		name       string
+		fn         func(ctx context.Context) error
+		wantErr    error
+		wantPanics int
+	}{
+		{
+			name: "success",
+			fn:   func(ctx context.Context) error { return nil },
+		},
+		{
+			name:    "error",
+			fn:      func(ctx context.Context) error { return errMock },
+			wantErr: errMock,
+		},
+		{
+			name:       "panic",
+			fn:         func(ctx context.Context) error { panic(errMock) },
+			wantErr:    errMock,
+			wantPanics: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				wg     sync.WaitGroup
+				mu     sync.Mutex
+				panics []*grab.PanicError
+			)
+			errs := make(chan error, 1)
+			grab.Go(context.Background(), tt.fn,
+				grab.WithErrors(errs),
+				grab.WithWaitGroup(&wg),
+				grab.WithPanicHandler(func(ctx context.Context, err *grab.PanicError) {
+					mu.Lock()
+					defer mu.Unlock()
+					panics = append(panics, err)
+				}),
+			)
+			wg.Wait()
+			close(errs)
+
+			err := <-errs
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, panics, tt.wantPanics)
+			if tt.wantPanics > 0 {
+				var panicErr *grab.PanicError
+				assert.ErrorAs(t, err, &panicErr)
+				assert.NotEmpty(t, panics[0].Stack)
+			}
+		})
+	}
+}
+
+func TestGoWithRecover(t *testing.T) {
+	got := make(chan *grab.PanicError, 1)
+	grab.GoWithRecover(context.Background(), func(ctx context.Context) {
+		panic("boom")
+	}, func(ctx context.Context, err *grab.PanicError) {
+		got <- err
+	})
+
+	err := <-got
+	assert.Equal(t, "boom", err.Value)
+	assert.EqualError(t, err, "goroutine panicked: boom")
+}
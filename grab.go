@@ -1,6 +1,14 @@
 package grab
 
-import "context"
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+)
 
 // Ptr takes any value of type 'T' and returns a pointer to a new copy of that value.
 // It is a generic function that can handle any type.
@@ -45,6 +53,96 @@ func Value[T any](o *T) T {
 	return *o
 }
 
+// Zero returns the zero value of type 'T'.
+// It is a generic function that can handle any type.
+//
+// Returns:
+// - T: The zero value of type 'T'.
+//
+// Example:
+//
+//	func (c *Cache[K, V]) Get(key K) (V, bool) {
+//	    if c == nil {
+//	        return Zero[V](), false
+//	    }
+//	    ...
+//	}
+//
+// Note: This function saves declaring 'var zero T' in generic code which needs to return the zero value.
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+// New returns a pointer to a new zero value of type 'T'.
+// It is a generic function that can handle any type.
+//
+// Returns:
+// - *T: A pointer to a new zero value of type 'T'.
+//
+// Example:
+// cfg := New[Config]() // cfg is a *Config with every field set to its zero value
+//
+// Note: This function is the generic equivalent of the built-in new, for use as a function value,
+// such as the constructor passed to NewPool.
+func New[T any]() *T {
+	return new(T)
+}
+
+// Default returns the first override if one is provided, and the zero value of type 'T' otherwise.
+// It is a generic function that can handle any type.
+//
+// Parameter:
+// - override: Optional values. Only the first is used.
+//
+// Returns:
+// - T: The first override, or the zero value of type 'T' if none are provided.
+//
+// Example:
+//
+//	func ListUsers(ctx context.Context, pageSize ...int) ([]User, error) {
+//	    size := Default(pageSize...)
+//	    ...
+//	}
+//
+// Note: This function is useful for functions which take an optional trailing argument as a variadic parameter.
+// Use FirstNonZero to skip overrides which are set to the zero value.
+func Default[T any](override ...T) T {
+	if len(override) == 0 {
+		return Zero[T]()
+	}
+	return override[0]
+}
+
+// BulkPtr returns a pointer to a copy of each value in a slice. All of the copies are stored in a
+// single backing array, so converting a large slice allocates once rather than once per value.
+// It is a generic function that can handle any type.
+//
+// Parameter:
+// - values: The values to be converted into pointers.
+//
+// Returns:
+// - []*T: A pointer to a copy of each value, in the same order as 'values'.
+//
+// Example:
+// names := []string{"alice", "bob"}
+// ptrs := BulkPtr(names) // ptrs[0] points to a copy of "alice", ptrs[1] to a copy of "bob"
+//
+// Note: Because the copies share a backing array, the array is kept alive for as long as any of the
+// pointers are reachable. This is useful when converting large listings into the pointer slices
+// expected by SDKs, where allocating each pointer separately puts pressure on the garbage collector.
+func BulkPtr[T any](values []T) []*T {
+	if values == nil {
+		return nil
+	}
+	backing := Freeze(values)
+	result := make([]*T, len(backing))
+	for i := range backing {
+		result[i] = &backing[i]
+	}
+	return result
+}
+
 // If evaluates a boolean condition and returns one of two values based on the result.
 // It is a generic function that works with any type (denoted by 'T').
 //
@@ -117,6 +215,126 @@ func IsZero[T comparable](value T) bool {
 	return value == zero
 }
 
+// IsNil reports whether 'v' is nil, including when it is an interface holding a nil pointer, map, slice,
+// channel or function. A plain comparison with nil misses these "typed nil" values, because the interface
+// itself is not nil.
+//
+// Parameters:
+//   - v: The value to check.
+//
+// Returns:
+//   - bool: Returns true if 'v' is nil or holds a nil value; otherwise, returns false.
+//
+// Example:
+// var p *MyError = nil
+// var err error = p
+//
+// isNil := IsNil(err)
+//
+// // isNil will be true, even though err != nil is also true
+//
+// Note: This function is useful for guarding against the classic (*T)(nil) != nil trap,
+// such as a function returning a nil *MyError as an error.
+func IsNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Compact returns a new slice containing the items of the input slice which are not the zero value for their type,
+// such as empty strings, zeros and nil pointers.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//
+// Returns:
+//   - []T: A new slice containing the non-zero items, in their original order.
+//
+// Example:
+// names := []string{"alice", "", "bob", ""}
+//
+// compacted := Compact(names)
+//
+// // compacted will be a []string: ["alice", "bob"]
+func Compact[T comparable](items []T) []T {
+	return Filter(items, Not(IsZero[T]))
+}
+
+// AppendIf appends 'v' to the slice if 'cond' is true, and returns the slice unchanged otherwise.
+// Like the built-in append, the result may share memory with 'items'.
+//
+// Parameters:
+//   - items: The slice to append to.
+//   - cond: Whether to append 'v'.
+//   - v: The value to append.
+//
+// Returns:
+//   - []T: The slice with 'v' appended if 'cond' is true.
+//
+// Example:
+// args := []string{"deploy"}
+// args = AppendIf(args, dryRun, "--dry-run")
+func AppendIf[T any](items []T, cond bool, v T) []T {
+	if !cond {
+		return items
+	}
+	return append(items, v)
+}
+
+// AppendNonZero appends each value which is not the zero value for its type, such as an empty string.
+// Like the built-in append, the result may share memory with 'items'.
+//
+// Parameters:
+//   - items: The slice to append to.
+//   - vs: The values to append. Zero values are skipped.
+//
+// Returns:
+//   - []T: The slice with the non-zero values appended.
+//
+// Example:
+// tags := AppendNonZero([]string{"team:platform"}, req.Environment, req.Owner)
+//
+// // tags will only contain req.Environment and req.Owner if they are set
+func AppendNonZero[T comparable](items []T, vs ...T) []T {
+	for _, v := range vs {
+		if !IsZero(v) {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// AppendUnique appends each value which is not already in the slice, and isn't repeated earlier in 'vs'.
+// Like the built-in append, the result may share memory with 'items'.
+//
+// Parameters:
+//   - items: The slice to append to.
+//   - vs: The values to append. Values which are already present are skipped.
+//
+// Returns:
+//   - []T: The slice with the new values appended.
+//
+// Example:
+// scopes := AppendUnique([]string{"read"}, "write", "read")
+//
+// // scopes will be a []string: ["read", "write"]
+//
+// Note: Checking for existing values scans 'items', so use a map to build large sets.
+func AppendUnique[T comparable](items []T, vs ...T) []T {
+	for _, v := range vs {
+		if !slices.Contains(items, v) {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
 // AllPages aggregates all items from a paginated API into a single slice.
 // It is a generic function that works with any type 'T' for the items and any comparable type 'Token' for pagination tokens.
 //
@@ -127,8 +345,8 @@ func IsZero[T comparable](value T) bool {
 //
 // Returns:
 //   - []T: A slice containing all aggregated items from all pages.
-//   - error: An error if any occurs during the fetching of pages. If an error is returned, the slice may contain
-//     the items fetched before the error occurred.
+//   - error: The error returned by 'fetchPage', unchanged, in which case the slice is nil.
+//     Use AllPagesWithHooks to attach the number of the page which failed to the error.
 //
 // Example:
 // items, err := AllPages[MyItem, string](ctx, myFetchPageFunc)
@@ -138,24 +356,7 @@ func IsZero[T comparable](value T) bool {
 // items from APIs that implement pagination. The user must provide a 'fetchPage' function that knows
 // how to retrieve a single page of items and the next pagination token.
 func AllPages[T any, Token comparable](ctx context.Context, fetchPage func(ctx context.Context, nextToken *Token) ([]T, *Token, error)) ([]T, error) {
-	var allItems []T
-	var nextToken *Token
-
-	for {
-		items, newToken, err := fetchPage(ctx, nextToken)
-		if err != nil {
-			return nil, err
-		}
-
-		allItems = append(allItems, items...)
-
-		if newToken == nil || IsZero(*newToken) {
-			break
-		}
-		nextToken = newToken
-	}
-
-	return allItems, nil
+	return allPages(ctx, nil, "", fetchPage, false)
 }
 
 // Map applies a transformation function to each item in a slice and returns a slice of the results.
@@ -243,66 +444,1201 @@ func Filter[T any](items []T, fn func(T) bool) []T {
 	return result
 }
 
-// MapFromSlice creates a map from the given slice where the elements of the slice are the keys and the value is a generic type.
-// The value for each key is set to the provided 'value'.
+// Without returns a new slice containing the items of the input slice which are not in 'exclude'.
 //
 // Parameters:
-//   - items: A slice of items of type 'T'. These are the keys for the map.
-//   - value: The value to be associated with each key in the map.
+//   - items: A slice of items of type 'T'.
+//   - exclude: The items to leave out of the result.
 //
 // Returns:
-//   - map[T]F: A map where the keys are elements from the input slice and the value for each key is set to the provided 'value'.
+//   - []T: A new slice containing the remaining items, in their original order.
 //
 // Example:
-// originalSlice := []string{"apple", "banana", "orange"}
+// regions := []string{"us-east-1", "us-west-2", "eu-west-1"}
 //
-// newMap := MapFromSlice(originalSlice, true)
+// allowed := Without(regions, "us-west-2")
 //
-// // newMap will be a map[string]bool with keys {"apple": true, "banana": true, "orange": true}
-func MapFromSlice[T comparable, F any](items []T, value F) map[T]F {
-	result := make(map[T]F, len(items))
+// // allowed will be a []string: ["us-east-1", "eu-west-1"]
+func Without[T comparable](items []T, exclude ...T) []T {
+	return Filter(items, Not(In(exclude...)))
+}
+
+// RemoveBy returns a new slice containing the items of the input slice for which the predicate 'fn' returns false.
+// It is the inverse of Filter.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - fn: A predicate function. If 'fn' returns true, the item is left out of the result.
+//
+// Returns:
+//   - []T: A new slice containing the remaining items, in their original order.
+//
+// Example:
+//
+//	active := RemoveBy(users, func(u User) bool {
+//	    return u.Suspended
+//	})
+func RemoveBy[T any](items []T, fn func(T) bool) []T {
+	return Filter(items, Not(fn))
+}
+
+// Find returns the first item in a slice for which the predicate 'fn' returns true.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be searched.
+//   - fn: A predicate function that takes an item of type 'T' and returns true if it matches.
+//
+// Returns:
+//   - T: The first matching item, or the zero value of type 'T' if there is none.
+//   - bool: True if a matching item was found.
+//
+// Example:
+// users := []User{{Name: "alice", Admin: false}, {Name: "bob", Admin: true}}
+//
+//	admin, ok := Find(users, func(u User) bool {
+//	    return u.Admin
+//	})
+//
+// // admin will be bob and ok will be true
+func Find[T any](items []T, fn func(T) bool) (T, bool) {
+	i := FindIndex(items, fn)
+	if i < 0 {
+		var zero T
+		return zero, false
+	}
+	return items[i], true
+}
+
+// FindIndex returns the index of the first item in a slice for which the predicate 'fn' returns true.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be searched.
+//   - fn: A predicate function that takes an item of type 'T' and returns true if it matches.
+//
+// Returns:
+//   - int: The index of the first matching item, or -1 if there is none.
+//
+// Example:
+// i := FindIndex([]int{1, 4, 9}, func(n int) bool { return n > 3 }) // i will be 1
+func FindIndex[T any](items []T, fn func(T) bool) int {
+	for i, item := range items {
+		if fn(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Every reports whether the predicate 'fn' returns true for every item in a slice.
+// It stops at the first item for which 'fn' returns false, and returns true for an empty slice.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be checked.
+//   - fn: A predicate function that takes an item of type 'T' and returns a bool.
+//
+// Returns:
+//   - bool: True if 'fn' returns true for every item.
+//
+// Example:
+// allValid := Every(grants, func(g Grant) bool { return g.Valid() })
+func Every[T any](items []T, fn func(T) bool) bool {
+	return FindIndex(items, Not(fn)) < 0
+}
+
+// Some reports whether the predicate 'fn' returns true for at least one item in a slice.
+// It stops at the first item for which 'fn' returns true, and returns false for an empty slice.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be checked.
+//   - fn: A predicate function that takes an item of type 'T' and returns a bool.
+//
+// Returns:
+//   - bool: True if 'fn' returns true for any item.
+//
+// Example:
+// anyExpired := Some(grants, func(g Grant) bool { return g.Expired() })
+func Some[T any](items []T, fn func(T) bool) bool {
+	return FindIndex(items, fn) >= 0
+}
+
+// None reports whether the predicate 'fn' returns false for every item in a slice.
+// It stops at the first item for which 'fn' returns true, and returns true for an empty slice.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be checked.
+//   - fn: A predicate function that takes an item of type 'T' and returns a bool.
+//
+// Returns:
+//   - bool: True if 'fn' returns false for every item.
+//
+// Example:
+// noAdmins := None(users, func(u User) bool { return u.Admin })
+func None[T any](items []T, fn func(T) bool) bool {
+	return !Some(items, fn)
+}
+
+// Reduce aggregates the items of a slice into a single value by applying 'fn' to each item in order, from left to right.
+// It is a generic function that works with any item type 'T' and any accumulator type 'A'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be aggregated.
+//   - initial: The initial value of the accumulator.
+//   - fn: A function that takes the current accumulator and an item, and returns the new accumulator.
+//
+// Returns:
+//   - A: The final value of the accumulator, or 'initial' if the slice is empty.
+//
+// Example:
+// numbers := []int{1, 2, 3, 4}
+//
+//	sum := Reduce(numbers, 0, func(acc int, n int) int {
+//	    return acc + n
+//	})
+//
+// // sum will be 10
+//
+// Note: This function is useful for aggregating slices, such as computing sums or building maps,
+// without writing the loop by hand.
+func Reduce[T any, A any](items []T, initial A, fn func(A, T) A) A {
+	acc := initial
 	for _, item := range items {
-		result[item] = value
+		acc = fn(acc, item)
 	}
-	return result
+	return acc
 }
 
-// ChunkSlice splits the given slice into smaller slices (chunks) of the specified size.
+// ReduceRight behaves like Reduce, but applies 'fn' to the items in reverse order, from right to left.
 //
 // Parameters:
-//   - slice: A slice of items of type 'T'. These are the items to be chunked.
-//   - chunkSize: The size of each chunk.
+//   - items: A slice of items of type 'T'. These are the items to be aggregated.
+//   - initial: The initial value of the accumulator.
+//   - fn: A function that takes the current accumulator and an item, and returns the new accumulator.
 //
 // Returns:
-//   - [][]T: A slice of slices, where each inner slice contains up to 'chunkSize' items from the original slice.
+//   - A: The final value of the accumulator, or 'initial' if the slice is empty.
 //
 // Example:
-// originalSlice := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+// letters := []string{"a", "b", "c"}
 //
-// chunks := ChunkSlice(originalSlice, 3)
+//	reversed := ReduceRight(letters, "", func(acc string, s string) string {
+//	    return acc + s
+//	})
 //
-// // chunks will be a slice of slices of ints with the following structure: [[1, 2, 3], [4, 5, 6], [7, 8, 9]]
-func ChunkSlice[T any](slice []T, chunkSize int) [][]T {
-	// prevent infinite loops by checking first for these exit conditions
-	if len(slice) == 0 {
-		return nil
+// // reversed will be "cba"
+func ReduceRight[T any, A any](items []T, initial A, fn func(A, T) A) A {
+	acc := initial
+	for i := len(items) - 1; i >= 0; i-- {
+		acc = fn(acc, items[i])
 	}
-	if chunkSize == 0 {
-		return nil
+	return acc
+}
+
+// GroupBy groups the items of a slice into buckets by a key.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be grouped.
+//   - keyFn: A function that takes an item of type 'T' and returns the key of the bucket it belongs in.
+//
+// Returns:
+//   - map[K][]T: A map from each key to the items with that key, in the order they appear in 'items'.
+//
+// Example:
+// users := []User{{Name: "alice", Team: "eng"}, {Name: "bob", Team: "sales"}, {Name: "carol", Team: "eng"}}
+//
+//	byTeam := GroupBy(users, func(u User) string {
+//	    return u.Team
+//	})
+//
+// // byTeam will be a map[string][]User: {"eng": [alice, carol], "sales": [bob]}
+//
+// Note: For sequences rather than slices, use GroupBySeq.
+func GroupBy[T any, K comparable](items []T, keyFn func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for _, item := range items {
+		key := keyFn(item)
+		result[key] = append(result[key], item)
+	}
+	return result
+}
+
+// GroupByMap groups the items of a slice into buckets by a key, transforming each item into a value in the same pass.
+// It saves a GroupBy followed by a Map over each bucket.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be grouped.
+//   - fn: A function that takes an item of type 'T' and returns the key of its bucket and the value to store in it.
+//
+// Returns:
+//   - map[K][]V: A map from each key to the values with that key, in the order their items appear in 'items'.
+//
+// Example:
+// users := []User{{Name: "alice", Team: "eng"}, {Name: "bob", Team: "sales"}, {Name: "carol", Team: "eng"}}
+//
+//	namesByTeam := GroupByMap(users, func(u User) (string, string) {
+//	    return u.Team, u.Name
+//	})
+//
+// // namesByTeam will be a map[string][]string: {"eng": ["alice", "carol"], "sales": ["bob"]}
+func GroupByMap[T any, K comparable, V any](items []T, fn func(T) (K, V)) map[K][]V {
+	result := make(map[K][]V)
+	for _, item := range items {
+		key, value := fn(item)
+		result[key] = append(result[key], value)
 	}
+	return result
+}
 
-	var chunks [][]T
-	for i := 0; i < len(slice); i += chunkSize {
-		end := i + chunkSize
+// Uniq returns the distinct items of a slice, keeping the first occurrence of each item in its original order.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be deduplicated.
+//
+// Returns:
+//   - []T: A new slice containing each distinct item once.
+//
+// Example:
+// ids := []string{"a", "b", "a", "c", "b"}
+//
+// unique := Uniq(ids)
+//
+// // unique will be a []string: ["a", "b", "c"]
+func Uniq[T comparable](items []T) []T {
+	return UniqBy(items, func(item T) T { return item })
+}
 
-		// necessary check to avoid slicing beyond
-		// slice capacity
-		if end > len(slice) {
-			end = len(slice)
+// UniqBy returns the items of a slice with distinct keys, keeping the first item with each key in its original order.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be deduplicated.
+//   - keyFn: A function that takes an item of type 'T' and returns the key that identifies it.
+//
+// Returns:
+//   - []T: A new slice containing the first item with each distinct key.
+//
+// Example:
+//
+//	users := UniqBy(allUsers, func(u User) string {
+//	    return u.ID
+//	})
+//
+// Note: This function is useful for deduplicating results from AllPages, where an item may appear on more than one page
+// if the underlying data changes during pagination.
+func UniqBy[T any, K comparable](items []T, keyFn func(T) K) []T {
+	seen := make(map[K]struct{}, len(items))
+	var result []T
+	for _, item := range items {
+		key := keyFn(item)
+		if _, ok := seen[key]; ok {
+			continue
 		}
-
-		chunks = append(chunks, slice[i:end])
+		seen[key] = struct{}{}
+		result = append(result, item)
 	}
+	return result
+}
 
-	return chunks
+// Intersect returns the distinct items which appear in both slices, in the order they appear in 'a'.
+//
+// Parameters:
+//   - a: A slice of items of type 'T'. The order of the result follows this slice.
+//   - b: A slice of items of type 'T'.
+//
+// Returns:
+//   - []T: A new slice containing each item which is in both 'a' and 'b' once.
+//
+// Example:
+// desired := []string{"alice", "bob", "carol"}
+// actual := []string{"carol", "dave", "alice"}
+//
+// unchanged := Intersect(desired, actual)
+//
+// // unchanged will be a []string: ["alice", "carol"]
+func Intersect[T comparable](a, b []T) []T {
+	inB := MapFromSlice(b, struct{}{})
+	return Uniq(Filter(a, func(item T) bool {
+		_, ok := inB[item]
+		return ok
+	}))
+}
+
+// Union returns the distinct items which appear in either slice, with the items of 'a' first,
+// followed by the items of 'b' which are not in 'a'.
+//
+// Parameters:
+//   - a: A slice of items of type 'T'.
+//   - b: A slice of items of type 'T'.
+//
+// Returns:
+//   - []T: A new slice containing each item which is in 'a' or 'b' once.
+//
+// Example:
+// union := Union([]string{"alice", "bob"}, []string{"bob", "carol"})
+//
+// // union will be a []string: ["alice", "bob", "carol"]
+func Union[T comparable](a, b []T) []T {
+	all := make([]T, 0, len(a)+len(b))
+	all = append(append(all, a...), b...)
+	return Uniq(all)
+}
+
+// Difference returns the distinct items of 'a' which do not appear in 'b', in the order they appear in 'a'.
+//
+// Parameters:
+//   - a: A slice of items of type 'T'.
+//   - b: A slice of items of type 'T' to exclude.
+//
+// Returns:
+//   - []T: A new slice containing each item which is in 'a' but not in 'b' once.
+//
+// Example:
+// desired := []string{"alice", "bob", "carol"}
+// actual := []string{"carol", "dave", "alice"}
+//
+// toCreate := Difference(desired, actual)
+// toDelete := Difference(actual, desired)
+//
+// // toCreate will be ["bob"], and toDelete will be ["dave"]
+func Difference[T comparable](a, b []T) []T {
+	inB := MapFromSlice(b, struct{}{})
+	return Uniq(Filter(a, func(item T) bool {
+		_, ok := inB[item]
+		return !ok
+	}))
+}
+
+// Interleave merges several slices into one by taking an item from each slice in turn, round-robin.
+// When a slice runs out of items, the remaining slices continue to alternate.
+//
+// Parameters:
+//   - sources: The slices to merge.
+//
+// Returns:
+//   - []T: A new slice containing every item from every slice.
+//
+// Example:
+// okta := []string{"o1", "o2", "o3"}
+// azure := []string{"a1"}
+// google := []string{"g1", "g2"}
+//
+// mixed := Interleave(okta, azure, google)
+//
+// // mixed will be a []string: ["o1", "a1", "g1", "o2", "g2", "o3"]
+//
+// Note: This function is useful for fairly mixing results from several providers before truncating them with Take.
+func Interleave[T any](sources ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range sources {
+		total += len(s)
+		longest = max(longest, len(s))
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, total)
+	for i := range longest {
+		for _, s := range sources {
+			if i < len(s) {
+				result = append(result, s[i])
+			}
+		}
+	}
+	return result
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip combines two slices into a slice of pairs, pairing items at the same index.
+// It is a generic function that works with any types 'A' and 'B'.
+//
+// Parameters:
+//   - a: The slice providing the first value of each pair.
+//   - b: The slice providing the second value of each pair.
+//
+// Returns:
+//   - []Pair[A, B]: A slice of pairs. If the slices have different lengths, the result has the length of
+//     the shorter slice and the extra items in the longer slice are ignored.
+//
+// Example:
+// requestIDs := []string{"req-1", "req-2"}
+// responses := []int{200, 404}
+//
+// pairs := Zip(requestIDs, responses)
+//
+// // pairs will be a []Pair[string, int]: [{"req-1", 200}, {"req-2", 404}]
+func Zip[A any, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+	result := make([]Pair[A, B], n)
+	for i := range result {
+		result[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return result
+}
+
+// Unzip splits a slice of pairs into two slices, reversing Zip.
+//
+// Parameters:
+//   - pairs: A slice of pairs.
+//
+// Returns:
+//   - []A: The first value of each pair, in order.
+//   - []B: The second value of each pair, in order.
+//
+// Example:
+// requestIDs, responses := Unzip(pairs)
+func Unzip[A any, B any](pairs []Pair[A, B]) ([]A, []B) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	a := make([]A, len(pairs))
+	b := make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// Take returns the first 'n' items of a slice. If the slice has fewer than 'n' items, all of them are returned.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - n: The maximum number of items to return. Values less than 1 return an empty slice.
+//
+// Returns:
+//   - []T: The first 'n' items.
+//
+// Example:
+// page := Take(results, 50)
+//
+// Note: The result shares memory with 'items', but its capacity is limited to its length,
+// so appending to it never overwrites the remaining items.
+func Take[T any](items []T, n int) []T {
+	n = clampIndex(n, len(items))
+	return items[:n:n]
+}
+
+// Drop returns the items of a slice after the first 'n'. If the slice has fewer than 'n' items, it returns an empty slice.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - n: The number of items to skip. Values less than 1 return all items.
+//
+// Returns:
+//   - []T: The items after the first 'n'.
+//
+// Example:
+// rest := Drop(results, 50)
+//
+// Note: The result shares memory with 'items'.
+func Drop[T any](items []T, n int) []T {
+	return items[clampIndex(n, len(items)):]
+}
+
+// TakeWhile returns the longest prefix of a slice in which every item satisfies the predicate 'fn'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - fn: A predicate function. The prefix ends at the first item for which 'fn' returns false.
+//
+// Returns:
+//   - []T: The items before the first item which doesn't satisfy 'fn'.
+//
+// Example:
+//
+//	recent := TakeWhile(eventsNewestFirst, func(e Event) bool {
+//	    return e.Time.After(cutoff)
+//	})
+//
+// Note: The result shares memory with 'items', but its capacity is limited to its length.
+func TakeWhile[T any](items []T, fn func(T) bool) []T {
+	i := FindIndex(items, Not(fn))
+	if i < 0 {
+		i = len(items)
+	}
+	return items[:i:i]
+}
+
+// DropWhile returns the items of a slice after the longest prefix in which every item satisfies the predicate 'fn'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - fn: A predicate function. Items are skipped until the first item for which 'fn' returns false.
+//
+// Returns:
+//   - []T: The items from the first item which doesn't satisfy 'fn' onwards.
+//
+// Example:
+//
+//	body := DropWhile(lines, func(l string) bool {
+//	    return strings.HasPrefix(l, "#")
+//	})
+//
+// Note: The result shares memory with 'items'.
+func DropWhile[T any](items []T, fn func(T) bool) []T {
+	i := FindIndex(items, Not(fn))
+	if i < 0 {
+		i = len(items)
+	}
+	return items[i:]
+}
+
+// clampIndex limits 'i' to the range [0, n].
+func clampIndex(i, n int) int {
+	return max(0, min(i, n))
+}
+
+// InsertAt returns a new slice with 'values' inserted before the item at 'index'. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - index: The position the first inserted value will have in the result. It is clamped to the range [0, len(items)],
+//     so a negative index inserts at the start and an index past the end appends.
+//   - values: The values to insert.
+//
+// Returns:
+//   - []T: A new slice containing the items with the values inserted.
+//
+// Example:
+// steps := []string{"plan", "apply"}
+//
+// withReview := InsertAt(steps, 1, "review")
+//
+// // withReview will be a []string: ["plan", "review", "apply"]
+func InsertAt[T any](items []T, index int, values ...T) []T {
+	index = clampIndex(index, len(items))
+	result := make([]T, 0, len(items)+len(values))
+	result = append(result, items[:index]...)
+	result = append(result, values...)
+	return append(result, items[index:]...)
+}
+
+// RemoveAt returns a new slice without the item at 'index'. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - index: The position of the item to remove. If it is out of range, no item is removed.
+//
+// Returns:
+//   - []T: A new slice containing the remaining items.
+//
+// Example:
+// steps := []string{"plan", "review", "apply"}
+//
+// withoutReview := RemoveAt(steps, 1)
+//
+// // withoutReview will be a []string: ["plan", "apply"]
+func RemoveAt[T any](items []T, index int) []T {
+	if index < 0 || index >= len(items) {
+		return Freeze(items)
+	}
+	result := make([]T, 0, len(items)-1)
+	result = append(result, items[:index]...)
+	return append(result, items[index+1:]...)
+}
+
+// Shuffle returns a new slice containing the items of the input slice in a random order. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be shuffled.
+//   - rng: The source of randomness. Pass a seeded source for deterministic results in tests.
+//     If nil, a time-seeded source is used.
+//
+// Returns:
+//   - []T: A new slice containing every item in a random order.
+//
+// Example:
+// endpoints := Shuffle(regionalEndpoints, nil)
+//
+// // spread load by trying the endpoints in a different order on each call
+func Shuffle[T any](items []T, rng *rand.Rand) []T {
+	return Sample(items, len(items), rng)
+}
+
+// Sample returns 'n' items chosen at random from the input slice, without replacement. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to choose from.
+//   - n: The number of items to choose. It is clamped to the range [0, len(items)].
+//   - rng: The source of randomness. Pass a seeded source for deterministic results in tests.
+//     If nil, a time-seeded source is used.
+//
+// Returns:
+//   - []T: A new slice containing the chosen items, in a random order.
+//
+// Example:
+// rng := rand.New(rand.NewSource(42))
+//
+// canaries := Sample(accounts, 3, rng)
+//
+// // canaries will be the same 3 accounts every time the test runs
+func Sample[T any](items []T, n int, rng *rand.Rand) []T {
+	if items == nil {
+		return nil
+	}
+	if rng == nil {
+		rng = Config{}.randOrDefault()
+	}
+	n = clampIndex(n, len(items))
+
+	// a partial Fisher-Yates shuffle, which only randomises the first 'n' positions
+	result := slices.Clone(items)
+	for i := range n {
+		j := i + rng.Intn(len(result)-i)
+		result[i], result[j] = result[j], result[i]
+	}
+	return result[:n:n]
+}
+
+// SortWith returns a new slice containing the items sorted by a key, using a custom less function to compare keys.
+// The sort is stable, so items with equal keys keep their original order. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be sorted.
+//   - keyFn: A function that takes an item of type 'T' and returns the key to sort by.
+//   - less: A function that reports whether key 'a' sorts before key 'b', such as NaturalLess or CaseInsensitiveLess.
+//
+// Returns:
+//   - []T: A new slice containing the sorted items.
+//
+// Example:
+// servers := []Server{{Name: "server10"}, {Name: "server2"}}
+//
+//	sorted := SortWith(servers, func(s Server) string {
+//	    return s.Name
+//	}, NaturalLess)
+//
+// // sorted will be [{Name: "server2"}, {Name: "server10"}]
+func SortWith[T any, K any](items []T, keyFn func(T) K, less func(a, b K) bool) []T {
+	result := slices.Clone(items)
+	slices.SortStableFunc(result, func(a, b T) int {
+		ka, kb := keyFn(a), keyFn(b)
+		switch {
+		case less(ka, kb):
+			return -1
+		case less(kb, ka):
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+// SortConfig holds the optional settings for SortBy and SortStableBy.
+type SortConfig struct {
+	// Descending sorts from the largest key to the smallest.
+	Descending bool
+}
+
+// Descending sorts from the largest key to the smallest in SortBy and SortStableBy.
+func Descending() Option[SortConfig] {
+	return WithField(func(c *SortConfig) *bool { return &c.Descending }, true)
+}
+
+// SortBy returns a new slice containing the items sorted by a key, from the smallest key to the largest.
+// The sort is not stable, so items with equal keys may be reordered. The input slice is not modified.
+// It is a generic function that works with any item type 'T' and any ordered key type 'K'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be sorted.
+//   - keyFn: A function that takes an item of type 'T' and returns the key to sort by.
+//   - opts: Optional settings. Descending sorts from the largest key to the smallest.
+//
+// Returns:
+//   - []T: A new slice containing the sorted items.
+//
+// Example:
+//
+//	newestFirst := SortBy(grants, func(g Grant) int64 {
+//	    return g.CreatedAt.Unix()
+//	}, Descending())
+//
+// Note: Use SortStableBy to keep items with equal keys in their original order, and SortWith to compare
+// keys with a custom function such as NaturalLess.
+func SortBy[T any, K cmp.Ordered](items []T, keyFn func(T) K, opts ...Option[SortConfig]) []T {
+	result := slices.Clone(items)
+	slices.SortFunc(result, compareBy(keyFn, opts))
+	return result
+}
+
+// SortStableBy behaves like SortBy, but keeps items with equal keys in their original order.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be sorted.
+//   - keyFn: A function that takes an item of type 'T' and returns the key to sort by.
+//   - opts: Optional settings. Descending sorts from the largest key to the smallest.
+//
+// Returns:
+//   - []T: A new slice containing the sorted items.
+func SortStableBy[T any, K cmp.Ordered](items []T, keyFn func(T) K, opts ...Option[SortConfig]) []T {
+	result := slices.Clone(items)
+	slices.SortStableFunc(result, compareBy(keyFn, opts))
+	return result
+}
+
+// compareBy returns a comparison function which orders items by the key returned by 'keyFn'.
+func compareBy[T any, K cmp.Ordered](keyFn func(T) K, opts []Option[SortConfig]) func(a, b T) int {
+	var cfg SortConfig
+	ApplyOptions(&cfg, opts...)
+	if cfg.Descending {
+		return func(a, b T) int { return cmp.Compare(keyFn(b), keyFn(a)) }
+	}
+	return func(a, b T) int { return cmp.Compare(keyFn(a), keyFn(b)) }
+}
+
+// Reverse returns a new slice containing the items of the input slice in reverse order. The input slice is not modified.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//
+// Returns:
+//   - []T: A new slice with the items in reverse order.
+//
+// Example:
+// numbers := []int{1, 2, 3}
+//
+// reversed := Reverse(numbers)
+//
+// // reversed will be a []int: [3, 2, 1], and numbers will still be [1, 2, 3]
+func Reverse[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, len(items))
+	for i, item := range items {
+		result[len(items)-1-i] = item
+	}
+	return result
+}
+
+// ReverseInPlace reverses the order of the items in a slice without allocating.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. It is modified in place.
+//
+// Example:
+// numbers := []int{1, 2, 3}
+//
+// ReverseInPlace(numbers)
+//
+// // numbers will be a []int: [3, 2, 1]
+func ReverseInPlace[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+// CountBy counts the items of a slice by a key.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be counted.
+//   - keyFn: A function that takes an item of type 'T' and returns the key it is counted under.
+//
+// Returns:
+//   - map[K]int: A map from each key to the number of items with that key.
+//
+// Example:
+// requests := []AccessRequest{{Status: "approved"}, {Status: "pending"}, {Status: "approved"}}
+//
+//	byStatus := CountBy(requests, func(r AccessRequest) string {
+//	    return r.Status
+//	})
+//
+// // byStatus will be a map[string]int: {"approved": 2, "pending": 1}
+func CountBy[T any, K comparable](items []T, keyFn func(T) K) map[K]int {
+	result := make(map[K]int)
+	for _, item := range items {
+		result[keyFn(item)]++
+	}
+	return result
+}
+
+// ErrDuplicateKey is returned by KeyByStrict when two items have the same key.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// KeyBy builds a lookup map from a slice, keyed by a value derived from each item.
+// If several items have the same key, the last one wins.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the values of the map.
+//   - keyFn: A function that takes an item of type 'T' and returns its key.
+//
+// Returns:
+//   - map[K]T: A map from each key to the last item with that key.
+//
+// Example:
+//
+//	byID := KeyBy(resources, func(r Resource) string {
+//	    return r.ID
+//	})
+//
+// // byID["i-123"] will be the resource with ID "i-123"
+//
+// Note: Use KeyByStrict if duplicate keys indicate a problem with the data.
+func KeyBy[T any, K comparable](items []T, keyFn func(T) K) map[K]T {
+	result := make(map[K]T, len(items))
+	for _, item := range items {
+		result[keyFn(item)] = item
+	}
+	return result
+}
+
+// KeyByStrict behaves like KeyBy, but returns an error if several items have the same key.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the values of the map.
+//   - keyFn: A function that takes an item of type 'T' and returns its key.
+//
+// Returns:
+//   - map[K]T: A map from each key to the item with that key.
+//   - error: An error wrapping ErrDuplicateKey if two items have the same key.
+func KeyByStrict[T any, K comparable](items []T, keyFn func(T) K) (map[K]T, error) {
+	result := make(map[K]T, len(items))
+	for _, item := range items {
+		key := keyFn(item)
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+		}
+		result[key] = item
+	}
+	return result, nil
+}
+
+// MapFromSlice creates a map from the given slice where the elements of the slice are the keys and the value is a generic type.
+// The value for each key is set to the provided 'value'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the keys for the map.
+//   - value: The value to be associated with each key in the map.
+//
+// Returns:
+//   - map[T]F: A map where the keys are elements from the input slice and the value for each key is set to the provided 'value'.
+//
+// Example:
+// originalSlice := []string{"apple", "banana", "orange"}
+//
+// newMap := MapFromSlice(originalSlice, true)
+//
+// // newMap will be a map[string]bool with keys {"apple": true, "banana": true, "orange": true}
+func MapFromSlice[T comparable, F any](items []T, value F) map[T]F {
+	result := make(map[T]F, len(items))
+	for _, item := range items {
+		result[item] = value
+	}
+	return result
+}
+
+// Associate creates a map from the given slice, deriving both the key and the value from each item.
+// If several items produce the same key, the last one wins.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - fn: A function that takes an item of type 'T' and returns its key and value.
+//
+// Returns:
+//   - map[K]V: A map containing the key and value returned by 'fn' for each item.
+//
+// Example:
+// users := []User{{ID: "u1", Email: "alice@example.com"}, {ID: "u2", Email: "bob@example.com"}}
+//
+//	emails := Associate(users, func(u User) (string, string) {
+//	    return u.ID, u.Email
+//	})
+//
+// // emails will be a map[string]string: {"u1": "alice@example.com", "u2": "bob@example.com"}
+func Associate[T any, K comparable, V any](items []T, fn func(T) (K, V)) map[K]V {
+	result := make(map[K]V, len(items))
+	for _, item := range items {
+		k, v := fn(item)
+		result[k] = v
+	}
+	return result
+}
+
+// Invert returns a new map with the keys and values of the input map swapped, such as to turn an ID-to-name
+// lookup table into a name-to-ID one.
+//
+// Parameters:
+//   - m: The map to invert.
+//
+// Returns:
+//   - map[V]K: A map from each value of 'm' to its key.
+//
+// Example:
+// namesByID := map[string]string{"u1": "alice", "u2": "bob"}
+//
+// idsByName := Invert(namesByID)
+//
+// // idsByName will be a map[string]string: {"alice": "u1", "bob": "u2"}
+//
+// Note: If several keys have the same value, only one of them is kept, and which one is unspecified
+// because map iteration order is random. Use InvertStrict if values may be duplicated.
+func Invert[K comparable, V comparable](m map[K]V) map[V]K {
+	result := make(map[V]K, len(m))
+	for k, v := range m {
+		result[v] = k
+	}
+	return result
+}
+
+// InvertStrict behaves like Invert, but returns an error if several keys have the same value.
+//
+// Parameters:
+//   - m: The map to invert.
+//
+// Returns:
+//   - map[V]K: A map from each value of 'm' to its key.
+//   - error: An error wrapping ErrDuplicateKey if a value appears more than once.
+func InvertStrict[K comparable, V comparable](m map[K]V) (map[V]K, error) {
+	result := make(map[V]K, len(m))
+	for k, v := range m {
+		if _, ok := result[v]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, v)
+		}
+		result[v] = k
+	}
+	return result, nil
+}
+
+// MergeMaps merges several maps into a new map. If a key is present in more than one map,
+// the value from the last map wins, so defaults can be overlaid with overrides.
+//
+// Parameters:
+//   - maps: The maps to merge, in increasing order of precedence. Nil maps are skipped.
+//
+// Returns:
+//   - map[K]V: A new map containing every key of the input maps. The input maps are not modified.
+//
+// Example:
+// defaults := map[string]string{"region": "us-east-1", "log_level": "info"}
+// overrides := map[string]string{"log_level": "debug"}
+//
+// config := MergeMaps(defaults, overrides)
+//
+// // config will be a map[string]string: {"region": "us-east-1", "log_level": "debug"}
+func MergeMaps[K comparable, V any](maps ...map[K]V) map[K]V {
+	return MergeMapsWith(func(_ K, _, v V) V { return v }, maps...)
+}
+
+// MergeMapsWith merges several maps into a new map, calling 'resolve' to pick the value for a key
+// which is present in more than one map.
+//
+// Parameters:
+//   - resolve: A function that returns the value to keep for 'key', given the 'existing' value merged so far
+//     and the 'incoming' value from the next map.
+//   - maps: The maps to merge, in order. Nil maps are skipped.
+//
+// Returns:
+//   - map[K]V: A new map containing every key of the input maps. The input maps are not modified.
+//
+// Example:
+//
+//	// keep the first non-empty value for each key
+//	config := MergeMapsWith(func(key string, existing, incoming string) string {
+//	    return FirstNonZero(existing, incoming)
+//	}, overrides, defaults)
+func MergeMapsWith[K comparable, V any](resolve func(key K, existing, incoming V) V, maps ...map[K]V) map[K]V {
+	size := 0
+	for _, m := range maps {
+		size = max(size, len(m))
+	}
+	result := make(map[K]V, size)
+	for _, m := range maps {
+		for k, v := range m {
+			if existing, ok := result[k]; ok {
+				v = resolve(k, existing, v)
+			}
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// MapKeys applies a transformation function to each key of a map and returns a new map with the transformed keys.
+//
+// Parameters:
+//   - m: The map to transform.
+//   - fn: A function that returns the new key for each key of 'm'.
+//
+// Returns:
+//   - map[K2]V: A new map with the transformed keys and the original values.
+//
+// Example:
+// usersByEmail := map[string]User{"Alice@Example.com": alice}
+//
+// normalized := MapKeys(usersByEmail, strings.ToLower)
+//
+// // normalized will be a map[string]User: {"alice@example.com": alice}
+//
+// Note: If 'fn' returns the same key for several keys, only one of their values is kept, and which one
+// is unspecified because map iteration order is random.
+func MapKeys[K1 comparable, K2 comparable, V any](m map[K1]V, fn func(K1) K2) map[K2]V {
+	result := make(map[K2]V, len(m))
+	for k, v := range m {
+		result[fn(k)] = v
+	}
+	return result
+}
+
+// MapValues applies a transformation function to each value of a map and returns a new map with the same keys.
+//
+// Parameters:
+//   - m: The map to transform.
+//   - fn: A function that returns the new value for each value of 'm'.
+//
+// Returns:
+//   - map[K]F: A new map with the original keys and the transformed values.
+//
+// Example:
+// usersByID := map[string]User{"u1": alice, "u2": bob}
+//
+// emails := MapValues(usersByID, func(u User) string { return u.Email })
+//
+// // emails will be a map[string]string: {"u1": "alice@example.com", "u2": "bob@example.com"}
+func MapValues[K comparable, V any, F any](m map[K]V, fn func(V) F) map[K]F {
+	result := make(map[K]F, len(m))
+	for k, v := range m {
+		result[k] = fn(v)
+	}
+	return result
+}
+
+// PickBy returns a new map containing the entries of 'm' for which the predicate returns true.
+// It is the map counterpart of Filter.
+//
+// Parameters:
+//   - m: The map to filter.
+//   - fn: A predicate called with each key and value.
+//
+// Returns:
+//   - map[K]V: A new map with the entries that satisfy the predicate. The input map is not modified.
+//
+// Example:
+// grantsByID := map[string]Grant{"gr_1": {Status: "active"}, "gr_2": {Status: "expired"}}
+//
+// active := PickBy(grantsByID, func(id string, g Grant) bool { return g.Status == "active" })
+//
+// // active will be a map[string]Grant: {"gr_1": {Status: "active"}}
+func PickBy[K comparable, V any](m map[K]V, fn func(K, V) bool) map[K]V {
+	result := make(map[K]V)
+	for k, v := range m {
+		if fn(k, v) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// OmitBy returns a new map without the entries of 'm' for which the predicate returns true.
+// It is the opposite of PickBy.
+//
+// Parameters:
+//   - m: The map to filter.
+//   - fn: A predicate called with each key and value.
+//
+// Returns:
+//   - map[K]V: A new map with the entries that don't satisfy the predicate. The input map is not modified.
+//
+// Example:
+// tags := map[string]string{"team": "platform", "owner": ""}
+//
+// set := OmitBy(tags, func(k, v string) bool { return v == "" })
+//
+// // set will be a map[string]string: {"team": "platform"}
+func OmitBy[K comparable, V any](m map[K]V, fn func(K, V) bool) map[K]V {
+	return PickBy(m, func(k K, v V) bool { return !fn(k, v) })
+}
+
+// Chunk splits a slice into batches of at most 'size' items, for APIs with a maximum batch size.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'. These are the items to be batched.
+//   - size: The maximum number of items in each batch.
+//
+// Returns:
+//   - [][]T: A slice of batches. Every batch except the last contains exactly 'size' items, and the last batch
+//     contains the remaining items. It returns nil if 'items' is empty or 'size' is less than 1.
+//
+// Example:
+// ids := []string{"a", "b", "c", "d", "e"}
+//
+// batches := Chunk(ids, 2)
+//
+// // batches will be a [][]string: [["a", "b"], ["c", "d"], ["e"]]
+//
+// Note: The batches share memory with 'items', but their capacity is limited to their length,
+// so appending to one batch never overwrites the items in the next.
+func Chunk[T any](items []T, size int) [][]T {
+	if len(items) == 0 || size < 1 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for i := 0; i < len(items); i += size {
+		end := min(i+size, len(items))
+		chunks = append(chunks, items[i:end:end])
+	}
+	return chunks
+}
+
+// Window returns every run of 'size' consecutive items in a slice, such as for computing moving averages.
+// Consecutive windows overlap, each starting one item after the previous one.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - size: The number of items in each window.
+//
+// Returns:
+//   - [][]T: A slice of len(items)-size+1 windows. It returns nil if 'size' is less than 1 or greater than len(items).
+//
+// Example:
+// latencies := []int{10, 20, 30, 40}
+//
+// windows := Window(latencies, 3)
+//
+// // windows will be a [][]int: [[10, 20, 30], [20, 30, 40]]
+//
+// Note: The windows share memory with 'items', but their capacity is limited to their length,
+// so appending to one window never overwrites the items in the next.
+func Window[T any](items []T, size int) [][]T {
+	if size < 1 || size > len(items) {
+		return nil
+	}
+
+	windows := make([][]T, 0, len(items)-size+1)
+	for i := 0; i+size <= len(items); i++ {
+		windows = append(windows, items[i:i+size:i+size])
+	}
+	return windows
+}
+
+// ChunkSlice splits the given slice into smaller slices (chunks) of the specified size.
+//
+// Parameters:
+//   - slice: A slice of items of type 'T'. These are the items to be chunked.
+//   - chunkSize: The size of each chunk.
+//
+// Returns:
+//   - [][]T: A slice of slices, where each inner slice contains up to 'chunkSize' items from the original slice.
+//
+// Example:
+// originalSlice := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+//
+// chunks := ChunkSlice(originalSlice, 3)
+//
+// // chunks will be a slice of slices of ints with the following structure: [[1, 2, 3], [4, 5, 6], [7, 8, 9]]
+//
+// Note: ChunkSlice is equivalent to Chunk, and is kept for compatibility.
+func ChunkSlice[T any](slice []T, chunkSize int) [][]T {
+	return Chunk(slice, chunkSize)
 }
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/common-fate/grab"
@@ -50,6 +53,40 @@ func TestIf(t *testing.T) {
 	}
 }
 
+func TestZero(t *testing.T) {
+	assert.Equal(t, 0, grab.Zero[int]())
+	assert.Equal(t, "", grab.Zero[string]())
+	assert.Nil(t, grab.Zero[*int]())
+	assert.Nil(t, grab.Zero[error]())
+}
+
+func TestNew(t *testing.T) {
+	type config struct {
+		Region string
+	}
+	a, b := grab.New[config](), grab.New[config]()
+	assert.Equal(t, &config{}, a)
+	assert.NotSame(t, a, b)
+}
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		override []int
+		want     int
+	}{
+		{name: "no override", override: nil, want: 0},
+		{name: "one override", override: []int{50}, want: 50},
+		{name: "first override wins", override: []int{50, 100}, want: 50},
+		{name: "zero override is used", override: []int{0, 100}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Default(tt.override...))
+		})
+	}
+}
+
 func TestFirstNonZero(t *testing.T) {
 	type args struct {
 		elements []string
@@ -133,6 +170,21 @@ func TestAllPages(t *testing.T) {
 	}
 }
 
+func TestAllPagesUnwrappedError(t *testing.T) {
+	errThrottled := errors.New("throttled")
+	_, err := grab.AllPages(context.Background(), func(ctx context.Context, nextToken *int) ([]string, *int, error) {
+		if nextToken == nil {
+			return []string{"a"}, grab.Ptr(1), nil
+		}
+		return nil, nil, errThrottled
+	})
+
+	// the error from fetchPage is returned as it is, so callers can compare it with ==
+	assert.True(t, err == errThrottled)
+	_, ok := grab.FieldFromErr[int](err, "page")
+	assert.False(t, ok)
+}
+
 func TestIsZero(t *testing.T) {
 	type args[T comparable] struct {
 		value T
@@ -162,6 +214,102 @@ func TestIsZero(t *testing.T) {
 	}
 }
 
+type nilTestError struct{}
+
+func (*nilTestError) Error() string { return "nil test error" }
+
+func TestIsNil(t *testing.T) {
+	var nilErr *nilTestError
+	var typedNilErr error = nilErr
+
+	tests := []struct {
+		name string
+		v    any
+		want bool
+	}{
+		{name: "untyped nil", v: nil, want: true},
+		{name: "typed nil pointer in an interface", v: typedNilErr, want: true},
+		{name: "nil map", v: map[string]int(nil), want: true},
+		{name: "nil slice", v: []int(nil), want: true},
+		{name: "nil channel", v: (chan int)(nil), want: true},
+		{name: "nil function", v: (func())(nil), want: true},
+		{name: "non-nil pointer", v: &nilTestError{}, want: false},
+		{name: "empty slice", v: []int{}, want: false},
+		{name: "zero int", v: 0, want: false},
+		{name: "empty string", v: "", want: false},
+		{name: "struct", v: struct{}{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.IsNil(tt.v))
+		})
+	}
+
+	assert.True(t, typedNilErr != nil, "the interface holding a nil pointer should not compare equal to nil")
+}
+
+func TestCompact(t *testing.T) {
+	t.Run("strings", func(t *testing.T) {
+		assert.Equal(t, []string{"alice", "bob"}, grab.Compact([]string{"alice", "", "bob", ""}))
+	})
+
+	t.Run("pointers", func(t *testing.T) {
+		a, b := grab.Ptr(1), grab.Ptr(2)
+		assert.Equal(t, []*int{a, b}, grab.Compact([]*int{nil, a, nil, b}))
+	})
+
+	t.Run("all zero", func(t *testing.T) {
+		assert.Nil(t, grab.Compact([]int{0, 0}))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		assert.Nil(t, grab.Compact[string](nil))
+	})
+}
+
+func TestAppendIf(t *testing.T) {
+	assert.Equal(t, []string{"deploy", "--dry-run"}, grab.AppendIf([]string{"deploy"}, true, "--dry-run"))
+	assert.Equal(t, []string{"deploy"}, grab.AppendIf([]string{"deploy"}, false, "--dry-run"))
+	assert.Nil(t, grab.AppendIf(nil, false, "--dry-run"))
+}
+
+func TestAppendNonZero(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		vs    []string
+		want  []string
+	}{
+		{name: "zero values skipped", items: []string{"a"}, vs: []string{"", "b", ""}, want: []string{"a", "b"}},
+		{name: "all zero", items: []string{"a"}, vs: []string{"", ""}, want: []string{"a"}},
+		{name: "nil slice", items: nil, vs: []string{"b"}, want: []string{"b"}},
+		{name: "no values", items: nil, vs: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.AppendNonZero(tt.items, tt.vs...))
+		})
+	}
+}
+
+func TestAppendUnique(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		vs    []string
+		want  []string
+	}{
+		{name: "existing values skipped", items: []string{"read"}, vs: []string{"write", "read"}, want: []string{"read", "write"}},
+		{name: "repeated values appended once", items: nil, vs: []string{"a", "b", "a"}, want: []string{"a", "b"}},
+		{name: "no values", items: []string{"a"}, vs: nil, want: []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.AppendUnique(tt.items, tt.vs...))
+		})
+	}
+}
+
 func TestMap(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -274,105 +422,1170 @@ func TestFilter(t *testing.T) {
 	}
 }
 
-func TestMapFromSlice(t *testing.T) {
+func TestWithout(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		exclude []string
+		want    []string
+	}{
+		{name: "excluded items removed", items: []string{"a", "b", "c", "b"}, exclude: []string{"b"}, want: []string{"a", "c"}},
+		{name: "several exclusions", items: []string{"a", "b", "c"}, exclude: []string{"a", "c", "d"}, want: []string{"b"}},
+		{name: "nothing excluded", items: []string{"a", "b"}, exclude: nil, want: []string{"a", "b"}},
+		{name: "everything excluded", items: []string{"a"}, exclude: []string{"a"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Without(tt.items, tt.exclude...))
+		})
+	}
+}
+
+func TestRemoveBy(t *testing.T) {
+	got := grab.RemoveBy([]int{1, 2, 3, 4}, func(n int) bool { return n%2 == 0 })
+	assert.Equal(t, []int{1, 3}, got)
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []int
+		want      int
+		wantOK    bool
+		wantIndex int
+	}{
+		{
+			name:      "first match is returned",
+			items:     []int{1, 4, 9, 16},
+			want:      4,
+			wantOK:    true,
+			wantIndex: 1,
+		},
+		{
+			name:      "no match",
+			items:     []int{1, 2, 3},
+			want:      0,
+			wantOK:    false,
+			wantIndex: -1,
+		},
+		{
+			name:      "empty slice",
+			items:     nil,
+			want:      0,
+			wantOK:    false,
+			wantIndex: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := func(n int) bool { return n > 3 }
+			got, ok := grab.Find(tt.items, fn)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantIndex, grab.FindIndex(tt.items, fn))
+		})
+	}
+}
+
+func TestEverySomeNone(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []int
+		wantEvery bool
+		wantSome  bool
+		wantNone  bool
+	}{
+		{
+			name:      "all match",
+			items:     []int{2, 4, 6},
+			wantEvery: true,
+			wantSome:  true,
+			wantNone:  false,
+		},
+		{
+			name:      "some match",
+			items:     []int{1, 2, 3},
+			wantEvery: false,
+			wantSome:  true,
+			wantNone:  false,
+		},
+		{
+			name:      "none match",
+			items:     []int{1, 3, 5},
+			wantEvery: false,
+			wantSome:  false,
+			wantNone:  true,
+		},
+		{
+			name:      "empty slice",
+			items:     nil,
+			wantEvery: true,
+			wantSome:  false,
+			wantNone:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			even := func(n int) bool { return n%2 == 0 }
+			assert.Equal(t, tt.wantEvery, grab.Every(tt.items, even), "Every")
+			assert.Equal(t, tt.wantSome, grab.Some(tt.items, even), "Some")
+			assert.Equal(t, tt.wantNone, grab.None(tt.items, even), "None")
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		initial string
+		want    string
+		right   string
+	}{
+		{
+			name:    "concatenate",
+			items:   []string{"a", "b", "c"},
+			initial: ">",
+			want:    ">abc",
+			right:   ">cba",
+		},
+		{
+			name:    "empty slice",
+			items:   nil,
+			initial: ">",
+			want:    ">",
+			right:   ">",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			concat := func(acc string, s string) string { return acc + s }
+			assert.Equal(t, tt.want, grab.Reduce(tt.items, tt.initial, concat))
+			assert.Equal(t, tt.right, grab.ReduceRight(tt.items, tt.initial, concat))
+		})
+	}
+
+	t.Run("build map", func(t *testing.T) {
+		got := grab.Reduce([]string{"apple", "avocado", "banana"}, map[byte]int{}, func(acc map[byte]int, s string) map[byte]int {
+			acc[s[0]]++
+			return acc
+		})
+		assert.Equal(t, map[byte]int{'a': 2, 'b': 1}, got)
+	})
+}
+
+func TestGroupBy(t *testing.T) {
 	tests := []struct {
 		name  string
 		items []string
-		value bool
-		want  map[string]bool
+		want  map[int][]string
 	}{
 		{
-			name:  "all items mapped to true",
-			items: []string{"apple", "banana", "orange"},
-			value: true,
-			want:  map[string]bool{"apple": true, "banana": true, "orange": true},
+			name:  "group by length",
+			items: []string{"a", "bb", "c", "dd", "eee"},
+			want:  map[int][]string{1: {"a", "c"}, 2: {"bb", "dd"}, 3: {"eee"}},
 		},
 		{
-			name:  "all items mapped to false",
-			items: []string{"apple", "banana", "orange"},
-			value: false,
-			want:  map[string]bool{"apple": false, "banana": false, "orange": false},
+			name:  "empty slice",
+			items: nil,
+			want:  map[int][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.GroupBy(tt.items, func(s string) int { return len(s) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupByMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		want  map[int][]string
+	}{
+		{
+			name:  "group by length",
+			items: []string{"a", "bb", "c", "dd", "eee"},
+			want:  map[int][]string{1: {"A", "C"}, 2: {"BB", "DD"}, 3: {"EEE"}},
 		},
 		{
 			name:  "empty slice",
-			items: []string{},
-			value: true,
-			want:  map[string]bool{},
+			items: nil,
+			want:  map[int][]string{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := grab.MapFromSlice(tt.items, tt.value)
-
-			// Check if the length of the map matches the expected length
-			assert.Equal(t, len(tt.want), len(got), "Length of maps mismatch")
+			got := grab.GroupByMap(tt.items, func(s string) (int, string) { return len(s), strings.ToUpper(s) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
 
-			// Check if each key-value pair in 'want' exists in 'got'
-			for key, wantValue := range tt.want {
-				gotValue, exists := got[key]
-				assert.True(t, exists, fmt.Sprintf("Key %s does not exist in the result map", key))
-				assert.Equal(t, wantValue, gotValue, fmt.Sprintf("Value for key %s mismatch", key))
-			}
+func TestUniq(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		want  []string
+	}{
+		{
+			name:  "duplicates removed in first-occurrence order",
+			items: []string{"b", "a", "b", "c", "a"},
+			want:  []string{"b", "a", "c"},
+		},
+		{
+			name:  "no duplicates",
+			items: []string{"a", "b"},
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			want:  nil,
+		},
+	}
 
-			// Check if each key in 'got' exists in 'want'
-			for key := range got {
-				_, exists := tt.want[key]
-				assert.True(t, exists, fmt.Sprintf("Key %s should not exist in the result map", key))
-			}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Uniq(tt.items))
 		})
 	}
 }
 
-func TestChunkSlice(t *testing.T) {
+func TestUniqBy(t *testing.T) {
+	type user struct {
+		ID   string
+		Name string
+	}
+	users := []user{{ID: "1", Name: "alice"}, {ID: "2", Name: "bob"}, {ID: "1", Name: "alice (updated)"}}
+
+	got := grab.UniqBy(users, func(u user) string { return u.ID })
+	assert.Equal(t, []user{{ID: "1", Name: "alice"}, {ID: "2", Name: "bob"}}, got)
+}
+
+func TestSetOperations(t *testing.T) {
 	tests := []struct {
-		name      string
-		slice     []int
-		chunkSize int
-		expected  [][]int
+		name           string
+		a, b           []string
+		wantIntersect  []string
+		wantUnion      []string
+		wantDifference []string
 	}{
 		{
-			name:      "Chunk size divides slice evenly",
-			slice:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
-			chunkSize: 3,
-			expected:  [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			name:           "overlapping",
+			a:              []string{"alice", "bob", "carol"},
+			b:              []string{"carol", "dave", "alice"},
+			wantIntersect:  []string{"alice", "carol"},
+			wantUnion:      []string{"alice", "bob", "carol", "dave"},
+			wantDifference: []string{"bob"},
 		},
 		{
-			name:      "Chunk size does not divide slice evenly",
-			slice:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
-			chunkSize: 3,
-			expected:  [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10}},
+			name:           "duplicates removed",
+			a:              []string{"a", "b", "a", "c", "b"},
+			b:              []string{"b", "b", "d"},
+			wantIntersect:  []string{"b"},
+			wantUnion:      []string{"a", "b", "c", "d"},
+			wantDifference: []string{"a", "c"},
 		},
 		{
-			name:      "Chunk size greater than slice length",
-			slice:     []int{1, 2, 3},
-			chunkSize: 5,
-			expected:  [][]int{{1, 2, 3}},
+			name:           "disjoint",
+			a:              []string{"a"},
+			b:              []string{"b"},
+			wantIntersect:  nil,
+			wantUnion:      []string{"a", "b"},
+			wantDifference: []string{"a"},
 		},
 		{
-			name:      "Chunk size is 1",
-			slice:     []int{1, 2, 3, 4},
-			chunkSize: 1,
-			expected:  [][]int{{1}, {2}, {3}, {4}},
+			name:           "empty",
+			a:              nil,
+			b:              nil,
+			wantIntersect:  nil,
+			wantUnion:      nil,
+			wantDifference: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantIntersect, grab.Intersect(tt.a, tt.b))
+			assert.Equal(t, tt.wantUnion, grab.Union(tt.a, tt.b))
+			assert.Equal(t, tt.wantDifference, grab.Difference(tt.a, tt.b))
+		})
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources [][]string
+		want    []string
+	}{
+		{
+			name:    "equal lengths",
+			sources: [][]string{{"a1", "a2"}, {"b1", "b2"}},
+			want:    []string{"a1", "b1", "a2", "b2"},
 		},
 		{
-			name:      "Empty slice",
-			slice:     []int{},
-			chunkSize: 3,
-			expected:  nil,
+			name:    "different lengths",
+			sources: [][]string{{"o1", "o2", "o3"}, {"a1"}, {"g1", "g2"}},
+			want:    []string{"o1", "a1", "g1", "o2", "g2", "o3"},
 		},
 		{
-			name:      "Chunk size is 0",
-			slice:     []int{1, 2, 3},
-			chunkSize: 0,
-			expected:  nil,
+			name:    "empty source",
+			sources: [][]string{nil, {"b1", "b2"}},
+			want:    []string{"b1", "b2"},
+		},
+		{
+			name:    "no sources",
+			sources: nil,
+			want:    nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := grab.ChunkSlice(tt.slice, tt.chunkSize)
-			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.want, grab.Interleave(tt.sources...))
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []int
+		want []grab.Pair[string, int]
+	}{
+		{
+			name: "equal lengths",
+			a:    []string{"req-1", "req-2"},
+			b:    []int{200, 404},
+			want: []grab.Pair[string, int]{{First: "req-1", Second: 200}, {First: "req-2", Second: 404}},
+		},
+		{
+			name: "different lengths",
+			a:    []string{"req-1", "req-2", "req-3"},
+			b:    []int{200},
+			want: []grab.Pair[string, int]{{First: "req-1", Second: 200}},
+		},
+		{
+			name: "empty",
+			a:    nil,
+			b:    []int{200},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.Zip(tt.a, tt.b)
+			assert.Equal(t, tt.want, got)
+
+			a, b := grab.Unzip(got)
+			assert.Len(t, a, len(got))
+			assert.Len(t, b, len(got))
+			for i, p := range got {
+				assert.Equal(t, p.First, a[i])
+				assert.Equal(t, p.Second, b[i])
+			}
+		})
+	}
+}
+
+func TestTakeDrop(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []int
+		n        int
+		wantTake []int
+		wantDrop []int
+	}{
+		{name: "within bounds", items: []int{1, 2, 3, 4}, n: 2, wantTake: []int{1, 2}, wantDrop: []int{3, 4}},
+		{name: "n larger than slice", items: []int{1, 2}, n: 5, wantTake: []int{1, 2}, wantDrop: []int{}},
+		{name: "n zero", items: []int{1, 2}, n: 0, wantTake: []int{}, wantDrop: []int{1, 2}},
+		{name: "n negative", items: []int{1, 2}, n: -1, wantTake: []int{}, wantDrop: []int{1, 2}},
+		{name: "nil slice", items: nil, n: 2, wantTake: nil, wantDrop: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantTake, grab.Take(tt.items, tt.n))
+			assert.Equal(t, tt.wantDrop, grab.Drop(tt.items, tt.n))
+		})
+	}
+
+	t.Run("append to take does not overwrite", func(t *testing.T) {
+		items := []int{1, 2, 3}
+		_ = append(grab.Take(items, 1), 9)
+		assert.Equal(t, []int{1, 2, 3}, items)
+	})
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	positive := func(n int) bool { return n > 0 }
+
+	tests := []struct {
+		name     string
+		items    []int
+		wantTake []int
+		wantDrop []int
+	}{
+		{name: "prefix matches", items: []int{3, 1, -1, 2}, wantTake: []int{3, 1}, wantDrop: []int{-1, 2}},
+		{name: "all match", items: []int{1, 2}, wantTake: []int{1, 2}, wantDrop: []int{}},
+		{name: "none match", items: []int{-1, 2}, wantTake: []int{}, wantDrop: []int{-1, 2}},
+		{name: "nil slice", items: nil, wantTake: nil, wantDrop: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantTake, grab.TakeWhile(tt.items, positive))
+			assert.Equal(t, tt.wantDrop, grab.DropWhile(tt.items, positive))
+		})
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	tests := []struct {
+		name   string
+		items  []string
+		index  int
+		values []string
+		want   []string
+	}{
+		{name: "middle", items: []string{"a", "c"}, index: 1, values: []string{"b"}, want: []string{"a", "b", "c"}},
+		{name: "start", items: []string{"b"}, index: 0, values: []string{"a"}, want: []string{"a", "b"}},
+		{name: "end", items: []string{"a"}, index: 1, values: []string{"b", "c"}, want: []string{"a", "b", "c"}},
+		{name: "negative index prepends", items: []string{"b"}, index: -3, values: []string{"a"}, want: []string{"a", "b"}},
+		{name: "index past end appends", items: []string{"a"}, index: 9, values: []string{"b"}, want: []string{"a", "b"}},
+		{name: "no values", items: []string{"a"}, index: 0, values: nil, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.items)
+			assert.Equal(t, tt.want, grab.InsertAt(tt.items, tt.index, tt.values...))
+			assert.Equal(t, input, tt.items, "input should not be modified")
+		})
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		index int
+		want  []string
+	}{
+		{name: "middle", items: []string{"a", "b", "c"}, index: 1, want: []string{"a", "c"}},
+		{name: "first", items: []string{"a", "b"}, index: 0, want: []string{"b"}},
+		{name: "last", items: []string{"a", "b"}, index: 1, want: []string{"a"}},
+		{name: "only item", items: []string{"a"}, index: 0, want: []string{}},
+		{name: "negative index", items: []string{"a"}, index: -1, want: []string{"a"}},
+		{name: "index past end", items: []string{"a"}, index: 1, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.items)
+			got := grab.RemoveAt(tt.items, tt.index)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, input, tt.items, "input should not be modified")
+		})
+	}
+
+	t.Run("out of range returns a copy", func(t *testing.T) {
+		items := []string{"a"}
+		got := grab.RemoveAt(items, 5)
+		got[0] = "z"
+		assert.Equal(t, []string{"a"}, items)
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	items := seqInts(20)
+	input := slices.Clone(items)
+
+	got := grab.Shuffle(items, rand.New(rand.NewSource(1)))
+	assert.ElementsMatch(t, items, got)
+	assert.NotEqual(t, items, got)
+	assert.Equal(t, input, items, "input should not be modified")
+
+	again := grab.Shuffle(items, rand.New(rand.NewSource(1)))
+	assert.Equal(t, got, again, "the same seed should produce the same order")
+
+	assert.Nil(t, grab.Shuffle[int](nil, nil))
+}
+
+func TestSample(t *testing.T) {
+	items := seqInts(20)
+
+	tests := []struct {
+		name    string
+		n       int
+		wantLen int
+	}{
+		{name: "some items", n: 5, wantLen: 5},
+		{name: "all items", n: 20, wantLen: 20},
+		{name: "more than available", n: 25, wantLen: 20},
+		{name: "none", n: 0, wantLen: 0},
+		{name: "negative", n: -1, wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.Sample(items, tt.n, rand.New(rand.NewSource(1)))
+			assert.Len(t, got, tt.wantLen)
+			assert.Subset(t, items, got)
+			assert.Len(t, grab.Uniq(got), len(got), "items should be chosen without replacement")
+		})
+	}
+
+	t.Run("deterministic with a seed", func(t *testing.T) {
+		a := grab.Sample(items, 3, rand.New(rand.NewSource(7)))
+		b := grab.Sample(items, 3, rand.New(rand.NewSource(7)))
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("nil source", func(t *testing.T) {
+		assert.Len(t, grab.Sample(items, 3, nil), 3)
+	})
+}
+
+func TestSortWith(t *testing.T) {
+	type server struct {
+		Name string
+		ID   int
+	}
+
+	tests := []struct {
+		name  string
+		items []server
+		less  func(a, b string) bool
+		want  []server
+	}{
+		{
+			name:  "natural order",
+			items: []server{{Name: "server10"}, {Name: "server2"}, {Name: "server1"}},
+			less:  grab.NaturalLess,
+			want:  []server{{Name: "server1"}, {Name: "server2"}, {Name: "server10"}},
+		},
+		{
+			name:  "stable for equal keys",
+			items: []server{{Name: "B", ID: 1}, {Name: "a", ID: 2}, {Name: "b", ID: 3}},
+			less:  func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) },
+			want:  []server{{Name: "a", ID: 2}, {Name: "B", ID: 1}, {Name: "b", ID: 3}},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			less:  grab.NaturalLess,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.items)
+			got := grab.SortWith(tt.items, func(s server) string { return s.Name }, tt.less)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, input, tt.items, "input should not be modified")
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	type grant struct {
+		ID       string
+		Priority int
+	}
+	grants := []grant{{ID: "a", Priority: 2}, {ID: "b", Priority: 1}, {ID: "c", Priority: 2}, {ID: "d", Priority: 3}}
+	priority := func(g grant) int { return g.Priority }
+
+	t.Run("ascending", func(t *testing.T) {
+		got := grab.SortBy(grants, priority)
+		assert.Equal(t, []int{1, 2, 2, 3}, grab.Map(got, priority))
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		got := grab.SortBy(grants, priority, grab.Descending())
+		assert.Equal(t, []int{3, 2, 2, 1}, grab.Map(got, priority))
+	})
+
+	t.Run("stable keeps equal keys in order", func(t *testing.T) {
+		got := grab.SortStableBy(grants, priority)
+		assert.Equal(t, []grant{{ID: "b", Priority: 1}, {ID: "a", Priority: 2}, {ID: "c", Priority: 2}, {ID: "d", Priority: 3}}, got)
+	})
+
+	t.Run("stable descending", func(t *testing.T) {
+		got := grab.SortStableBy(grants, priority, grab.Descending())
+		assert.Equal(t, []grant{{ID: "d", Priority: 3}, {ID: "a", Priority: 2}, {ID: "c", Priority: 2}, {ID: "b", Priority: 1}}, got)
+	})
+
+	t.Run("input is not modified", func(t *testing.T) {
+		input := slices.Clone(grants)
+		grab.SortBy(grants, priority)
+		grab.SortStableBy(grants, priority)
+		assert.Equal(t, input, grants)
+	})
+
+	t.Run("string keys", func(t *testing.T) {
+		got := grab.SortBy([]string{"banana", "apple", "cherry"}, func(s string) string { return s })
+		assert.Equal(t, []string{"apple", "banana", "cherry"}, got)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		assert.Nil(t, grab.SortBy(nil, priority))
+	})
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		want  []int
+	}{
+		{
+			name:  "odd length",
+			items: []int{1, 2, 3},
+			want:  []int{3, 2, 1},
+		},
+		{
+			name:  "even length",
+			items: []int{1, 2, 3, 4},
+			want:  []int{4, 3, 2, 1},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.items)
+			assert.Equal(t, tt.want, grab.Reverse(tt.items))
+			assert.Equal(t, original, tt.items, "Reverse should not modify its input")
+
+			grab.ReverseInPlace(tt.items)
+			assert.Equal(t, tt.want, tt.items)
+		})
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		want  map[int]int
+	}{
+		{
+			name:  "count by length",
+			items: []string{"a", "bb", "c", "dd", "eee"},
+			want:  map[int]int{1: 2, 2: 2, 3: 1},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			want:  map[int]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.CountBy(tt.items, func(s string) int { return len(s) })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	type resource struct {
+		ID   string
+		Name string
+	}
+
+	tests := []struct {
+		name       string
+		items      []resource
+		want       map[string]resource
+		wantStrict map[string]resource
+		wantErr    error
+	}{
+		{
+			name:       "unique keys",
+			items:      []resource{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}},
+			want:       map[string]resource{"1": {ID: "1", Name: "a"}, "2": {ID: "2", Name: "b"}},
+			wantStrict: map[string]resource{"1": {ID: "1", Name: "a"}, "2": {ID: "2", Name: "b"}},
+		},
+		{
+			name:    "duplicate keys",
+			items:   []resource{{ID: "1", Name: "a"}, {ID: "1", Name: "b"}},
+			want:    map[string]resource{"1": {ID: "1", Name: "b"}},
+			wantErr: grab.ErrDuplicateKey,
+		},
+		{
+			name:       "empty slice",
+			items:      nil,
+			want:       map[string]resource{},
+			wantStrict: map[string]resource{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyFn := func(r resource) string { return r.ID }
+			assert.Equal(t, tt.want, grab.KeyBy(tt.items, keyFn))
+
+			got, err := grab.KeyByStrict(tt.items, keyFn)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStrict, got)
+		})
+	}
+}
+
+func TestMapFromSlice(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		value bool
+		want  map[string]bool
+	}{
+		{
+			name:  "all items mapped to true",
+			items: []string{"apple", "banana", "orange"},
+			value: true,
+			want:  map[string]bool{"apple": true, "banana": true, "orange": true},
+		},
+		{
+			name:  "all items mapped to false",
+			items: []string{"apple", "banana", "orange"},
+			value: false,
+			want:  map[string]bool{"apple": false, "banana": false, "orange": false},
+		},
+		{
+			name:  "empty slice",
+			items: []string{},
+			value: true,
+			want:  map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.MapFromSlice(tt.items, tt.value)
+
+			// Check if the length of the map matches the expected length
+			assert.Equal(t, len(tt.want), len(got), "Length of maps mismatch")
+
+			// Check if each key-value pair in 'want' exists in 'got'
+			for key, wantValue := range tt.want {
+				gotValue, exists := got[key]
+				assert.True(t, exists, fmt.Sprintf("Key %s does not exist in the result map", key))
+				assert.Equal(t, wantValue, gotValue, fmt.Sprintf("Value for key %s mismatch", key))
+			}
+
+			// Check if each key in 'got' exists in 'want'
+			for key := range got {
+				_, exists := tt.want[key]
+				assert.True(t, exists, fmt.Sprintf("Key %s should not exist in the result map", key))
+			}
+		})
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	type user struct {
+		ID    string
+		Email string
+	}
+
+	tests := []struct {
+		name  string
+		items []user
+		want  map[string]string
+	}{
+		{
+			name:  "keys and values derived",
+			items: []user{{ID: "u1", Email: "alice@example.com"}, {ID: "u2", Email: "bob@example.com"}},
+			want:  map[string]string{"u1": "alice@example.com", "u2": "bob@example.com"},
+		},
+		{
+			name:  "last duplicate wins",
+			items: []user{{ID: "u1", Email: "old@example.com"}, {ID: "u1", Email: "new@example.com"}},
+			want:  map[string]string{"u1": "new@example.com"},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.Associate(tt.items, func(u user) (string, string) {
+				return u.ID, u.Email
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInvert(t *testing.T) {
+	tests := []struct {
+		name       string
+		m          map[string]string
+		want       map[string]string
+		wantStrict error
+	}{
+		{
+			name: "unique values",
+			m:    map[string]string{"u1": "alice", "u2": "bob"},
+			want: map[string]string{"alice": "u1", "bob": "u2"},
+		},
+		{
+			name: "empty map",
+			m:    nil,
+			want: map[string]string{},
+		},
+		{
+			name:       "duplicate values",
+			m:          map[string]string{"u1": "alice", "u2": "alice"},
+			wantStrict: grab.ErrDuplicateKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grab.InvertStrict(tt.m)
+			if tt.wantStrict != nil {
+				assert.ErrorIs(t, err, tt.wantStrict)
+
+				// Invert keeps one of the keys
+				inverted := grab.Invert(tt.m)
+				assert.Len(t, inverted, 1)
+				assert.Contains(t, []string{"u1", "u2"}, inverted["alice"])
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, grab.Invert(tt.m))
+		})
+	}
+}
+
+func TestMergeMaps(t *testing.T) {
+	defaults := map[string]string{"region": "us-east-1", "log_level": "info"}
+	overrides := map[string]string{"log_level": "debug", "profile": ""}
+
+	tests := []struct {
+		name    string
+		resolve func(key, existing, incoming string) string
+		maps    []map[string]string
+		want    map[string]string
+	}{
+		{
+			name: "last wins",
+			maps: []map[string]string{defaults, overrides},
+			want: map[string]string{"region": "us-east-1", "log_level": "debug", "profile": ""},
+		},
+		{
+			name: "nil maps",
+			maps: []map[string]string{nil, defaults, nil},
+			want: map[string]string{"region": "us-east-1", "log_level": "info"},
+		},
+		{
+			name: "no maps",
+			maps: nil,
+			want: map[string]string{},
+		},
+		{
+			name: "resolver",
+			resolve: func(key, existing, incoming string) string {
+				return existing + "," + incoming
+			},
+			maps: []map[string]string{defaults, overrides, {"log_level": "warn"}},
+			want: map[string]string{"region": "us-east-1", "log_level": "info,debug,warn", "profile": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]string
+			if tt.resolve != nil {
+				got = grab.MergeMapsWith(tt.resolve, tt.maps...)
+			} else {
+				got = grab.MergeMaps(tt.maps...)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// the inputs are not modified
+	assert.Equal(t, map[string]string{"region": "us-east-1", "log_level": "info"}, defaults)
+}
+
+func TestMapKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[string]int
+		want map[string]int
+	}{
+		{
+			name: "transforms keys",
+			m:    map[string]int{"Alice": 1, "Bob": 2},
+			want: map[string]int{"alice": 1, "bob": 2},
+		},
+		{
+			name: "empty map",
+			m:    nil,
+			want: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.MapKeys(tt.m, strings.ToLower))
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[string]int
+		want map[string]string
+	}{
+		{
+			name: "transforms values",
+			m:    map[string]int{"a": 1, "b": 2},
+			want: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "empty map",
+			m:    nil,
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.MapValues(tt.m, func(v int) string { return fmt.Sprint(v) }))
+		})
+	}
+}
+
+func TestPickBy(t *testing.T) {
+	tags := map[string]string{"team": "platform", "owner": "", "env": "prod"}
+	isEmpty := func(k, v string) bool { return v == "" }
+
+	tests := []struct {
+		name     string
+		m        map[string]string
+		wantPick map[string]string
+		wantOmit map[string]string
+	}{
+		{
+			name:     "mixed",
+			m:        tags,
+			wantPick: map[string]string{"owner": ""},
+			wantOmit: map[string]string{"team": "platform", "env": "prod"},
+		},
+		{
+			name:     "empty map",
+			m:        nil,
+			wantPick: map[string]string{},
+			wantOmit: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantPick, grab.PickBy(tt.m, isEmpty))
+			assert.Equal(t, tt.wantOmit, grab.OmitBy(tt.m, isEmpty))
+		})
+	}
+
+	// the input is not modified
+	assert.Len(t, tags, 3)
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		size  int
+		want  [][]int
+	}{
+		{
+			name:  "final partial chunk",
+			items: []int{1, 2, 3, 4, 5},
+			size:  2,
+			want:  [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:  "size greater than length",
+			items: []int{1, 2},
+			size:  25,
+			want:  [][]int{{1, 2}},
+		},
+		{
+			name:  "empty slice",
+			items: nil,
+			size:  25,
+			want:  nil,
+		},
+		{
+			name:  "zero size",
+			items: []int{1, 2},
+			size:  0,
+			want:  nil,
+		},
+		{
+			name:  "negative size",
+			items: []int{1, 2},
+			size:  -1,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Chunk(tt.items, tt.size))
+		})
+	}
+
+	t.Run("appending does not overwrite the next chunk", func(t *testing.T) {
+		chunks := grab.Chunk([]int{1, 2, 3, 4}, 2)
+		_ = append(chunks[0], 99)
+		assert.Equal(t, []int{3, 4}, chunks[1])
+	})
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+		size  int
+		want  [][]int
+	}{
+		{name: "overlapping windows", items: []int{1, 2, 3, 4}, size: 3, want: [][]int{{1, 2, 3}, {2, 3, 4}}},
+		{name: "size one", items: []int{1, 2}, size: 1, want: [][]int{{1}, {2}}},
+		{name: "size equals length", items: []int{1, 2}, size: 2, want: [][]int{{1, 2}}},
+		{name: "size greater than length", items: []int{1, 2}, size: 3, want: nil},
+		{name: "size zero", items: []int{1, 2}, size: 0, want: nil},
+		{name: "empty slice", items: nil, size: 1, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Window(tt.items, tt.size))
+		})
+	}
+
+	t.Run("append to window does not overwrite", func(t *testing.T) {
+		items := []int{1, 2, 3}
+		windows := grab.Window(items, 2)
+		_ = append(windows[0], 9)
+		assert.Equal(t, []int{1, 2, 3}, items)
+	})
+}
+
+func TestChunkSlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     []int
+		chunkSize int
+		expected  [][]int
+	}{
+		{
+			name:      "Chunk size divides slice evenly",
+			slice:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			chunkSize: 3,
+			expected:  [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		},
+		{
+			name:      "Chunk size does not divide slice evenly",
+			slice:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			chunkSize: 3,
+			expected:  [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10}},
+		},
+		{
+			name:      "Chunk size greater than slice length",
+			slice:     []int{1, 2, 3},
+			chunkSize: 5,
+			expected:  [][]int{{1, 2, 3}},
+		},
+		{
+			name:      "Chunk size is 1",
+			slice:     []int{1, 2, 3, 4},
+			chunkSize: 1,
+			expected:  [][]int{{1}, {2}, {3}, {4}},
+		},
+		{
+			name:      "Empty slice",
+			slice:     []int{},
+			chunkSize: 3,
+			expected:  nil,
+		},
+		{
+			name:      "Chunk size is 0",
+			slice:     []int{1, 2, 3},
+			chunkSize: 0,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := grab.ChunkSlice(tt.slice, tt.chunkSize)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBulkPtr(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+	}{
+		{
+			name:   "nil",
+			values: nil,
+		},
+		{
+			name:   "empty",
+			values: []string{},
+		},
+		{
+			name:   "values",
+			values: []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.BulkPtr(tt.values)
+			assert.Equal(t, len(tt.values), len(got))
+			for i := range got {
+				assert.Equal(t, tt.values[i], *got[i])
+			}
+			if len(got) > 0 {
+				*got[0] = "changed"
+				assert.NotEqual(t, "changed", tt.values[0], "pointers refer to copies")
+			}
 		})
 	}
 }
@@ -0,0 +1,89 @@
+package grabtest
+
+import (
+	"math/rand"
+
+	"github.com/common-fate/grab"
+)
+
+// DefaultSeed is the seed used by GenSlice when no source of randomness is provided with grab.WithRand,
+// so that generated inputs are the same on every run.
+const DefaultSeed = 1
+
+// GenSlice generates a slice of 'n' values using a generator function, for randomised-but-reproducible table tests.
+// It is a generic function that works with any type 'T'.
+//
+// Parameters:
+//   - n: The number of values to generate.
+//   - gen: A function which generates the value at index 'i' using the source of randomness 'r'.
+//   - opts: Optional settings. grab.WithRand sets the source of randomness; if it is not provided,
+//     a source seeded with DefaultSeed is used.
+//
+// Returns:
+//   - []T: The generated values.
+//
+// Example:
+// ids := GenSlice(100, GenString(8))
+//
+//	filtered := grab.Filter(grab.Filter(ids, isA), isB)
+//	conjunction := grab.Filter(ids, func(s string) bool { return isA(s) && isB(s) })
+//	assert.Equal(t, conjunction, filtered)
+func GenSlice[T any](n int, gen func(i int, r *rand.Rand) T, opts ...grab.Option[grab.Config]) []T {
+	var cfg grab.Config
+	grab.ApplyOptions(&cfg, opts...)
+	r := cfg.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(DefaultSeed))
+	}
+
+	result := make([]T, n)
+	for i := range result {
+		result[i] = gen(i, r)
+	}
+	return result
+}
+
+// GenInt returns a generator of integers in the half-open interval [lo, hi): 'lo' is inclusive and 'hi' is exclusive.
+// If 'hi' is not greater than 'lo', it always generates 'lo'.
+func GenInt(lo, hi int) func(i int, r *rand.Rand) int {
+	return func(_ int, r *rand.Rand) int {
+		if hi <= lo {
+			return lo
+		}
+		return lo + r.Intn(hi-lo)
+	}
+}
+
+// GenBool returns a generator of booleans.
+func GenBool() func(i int, r *rand.Rand) bool {
+	return func(_ int, r *rand.Rand) bool {
+		return r.Intn(2) == 0
+	}
+}
+
+const genAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// GenString returns a generator of lowercase alphanumeric strings with lengths in the interval [0, maxLen].
+// A negative 'maxLen' generates empty strings.
+func GenString(maxLen int) func(i int, r *rand.Rand) string {
+	maxLen = max(maxLen, 0)
+	return func(_ int, r *rand.Rand) string {
+		b := make([]byte, r.Intn(maxLen+1))
+		for j := range b {
+			b[j] = genAlphabet[r.Intn(len(genAlphabet))]
+		}
+		return string(b)
+	}
+}
+
+// GenOneOf returns a generator which picks one of the provided values at random.
+// If no values are provided, it generates the zero value of 'T'.
+func GenOneOf[T any](values ...T) func(i int, r *rand.Rand) T {
+	return func(_ int, r *rand.Rand) T {
+		if len(values) == 0 {
+			var zero T
+			return zero
+		}
+		return values[r.Intn(len(values))]
+	}
+}
@@ -0,0 +1,63 @@
+package grabtest_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/common-fate/grab/grabtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenSlice(t *testing.T) {
+	t.Run("reproducible by default", func(t *testing.T) {
+		assert.Equal(t, grabtest.GenSlice(20, grabtest.GenInt(0, 1000)), grabtest.GenSlice(20, grabtest.GenInt(0, 1000)))
+	})
+
+	t.Run("seeded source", func(t *testing.T) {
+		a := grabtest.GenSlice(20, grabtest.GenString(8), grab.WithRand(rand.New(rand.NewSource(7))))
+		b := grabtest.GenSlice(20, grabtest.GenString(8), grab.WithRand(rand.New(rand.NewSource(7))))
+		assert.Equal(t, a, b)
+		for _, s := range a {
+			assert.LessOrEqual(t, len(s), 8)
+		}
+	})
+
+	t.Run("generators stay in range", func(t *testing.T) {
+		for _, n := range grabtest.GenSlice(100, grabtest.GenInt(5, 10)) {
+			assert.GreaterOrEqual(t, n, 5)
+			assert.Less(t, n, 10)
+		}
+		for _, s := range grabtest.GenSlice(100, grabtest.GenOneOf("a", "b")) {
+			assert.Contains(t, []string{"a", "b"}, s)
+		}
+		assert.Len(t, grabtest.GenSlice(3, grabtest.GenBool()), 3)
+	})
+
+	t.Run("index is passed to generator", func(t *testing.T) {
+		got := grabtest.GenSlice(3, func(i int, r *rand.Rand) int { return i })
+		assert.Equal(t, []int{0, 1, 2}, got)
+	})
+
+	t.Run("nil option", func(t *testing.T) {
+		assert.Len(t, grabtest.GenSlice(3, grabtest.GenBool(), nil), 3)
+	})
+
+	t.Run("degenerate generators", func(t *testing.T) {
+		assert.Equal(t, []string{"", ""}, grabtest.GenSlice(2, grabtest.GenString(-1)))
+		assert.Equal(t, []string{"", ""}, grabtest.GenSlice(2, grabtest.GenOneOf[string]()))
+	})
+}
+
+func TestFilterConjunctionProperty(t *testing.T) {
+	isLong := func(s string) bool { return len(s) > 3 }
+	hasA := func(s string) bool { return strings.Contains(s, "a") }
+
+	for seed := int64(0); seed < 10; seed++ {
+		items := grabtest.GenSlice(200, grabtest.GenString(8), grab.WithRand(rand.New(rand.NewSource(seed))))
+		composed := grab.Filter(grab.Filter(items, isLong), hasA)
+		conjunction := grab.Filter(items, func(s string) bool { return isLong(s) && hasA(s) })
+		assert.Equal(t, conjunction, composed)
+	}
+}
@@ -0,0 +1,90 @@
+// Package grabtest contains test helpers for code built with grab.
+package grabtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+// update is namespaced so that it can't clash with an -update flag defined by the test package using grabtest.
+// Imported packages are initialised first, so registering a plain -update flag here would make that
+// test package panic with "flag redefined".
+var update = flag.Bool("grabtest.update", false, "update grabtest golden files and recordings")
+
+// AssertGolden serializes a value to canonical JSON and compares it against the golden file
+// testdata/<name>.golden.json, failing the test with a diff if they differ.
+//
+// Run the tests with the -grabtest.update flag to write the current value to the golden file instead.
+// If the test package defines its own -update flag, that flag is respected too.
+//
+// Parameters:
+//   - t: The test to report failures to.
+//   - name: The name of the golden file, without the testdata directory or extension.
+//   - v: The value to compare. It must be serializable with encoding/json.
+//
+// Example:
+// AssertGolden(t, "sync_plan", plan)
+//
+// Note: This function is useful for asserting on large structs which are painful to compare field-by-field.
+// Map keys are sorted and the output is indented, so the golden files produce readable diffs in code review.
+func AssertGolden(t testing.TB, name string, v any) {
+	t.Helper()
+
+	got, err := canonicalJSON(v)
+	if err != nil {
+		t.Fatalf("grabtest: serializing %s: %s", name, err)
+	}
+
+	path := filepath.Join("testdata", name+".golden.json")
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("grabtest: creating golden file directory: %s", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("grabtest: writing golden file: %s", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("grabtest: reading golden file (run the tests with -grabtest.update to create it): %s", err)
+	}
+	assert.Equal(t, string(want), string(got), "output does not match golden file %s (run the tests with -grabtest.update to update it)", path)
+}
+
+// updating reports whether golden files and recordings should be written, because either -grabtest.update
+// or an -update flag defined by the test package is set. The -update flag is looked up when the test runs,
+// after flags have been parsed, rather than when grabtest is initialised.
+func updating() bool {
+	if *update {
+		return true
+	}
+	f := flag.Lookup("update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// canonicalJSON serializes a value as indented JSON with a trailing newline.
+// encoding/json sorts map keys, so the output is stable across runs.
+func canonicalJSON(v any) ([]byte, error) {
+	var out []byte
+	err := grab.WithBuffer(func(buf *bytes.Buffer) error {
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		out = bytes.Clone(buf.Bytes())
+		return nil
+	})
+	return out, err
+}
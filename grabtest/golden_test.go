@@ -0,0 +1,30 @@
+package grabtest_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/common-fate/grab/grabtest"
+)
+
+// test packages commonly define their own -update flag; grabtest must not register a flag with the same name.
+var update = flag.Bool("update", false, "update golden files")
+
+// updateFlagSet reports whether either flag AssertGolden and Replay respect was set.
+func updateFlagSet() bool {
+	return *update || flag.Lookup("grabtest.update").Value.String() == "true"
+}
+
+type plan struct {
+	Name    string            `json:"name"`
+	Inserts []string          `json:"inserts"`
+	Tags    map[string]string `json:"tags"`
+}
+
+func TestAssertGolden(t *testing.T) {
+	grabtest.AssertGolden(t, "plan", plan{
+		Name:    "sync",
+		Inserts: []string{"a", "b"},
+		Tags:    map[string]string{"z": "last", "a": "first", "html": "<b>"},
+	})
+}
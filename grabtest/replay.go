@@ -0,0 +1,135 @@
+package grabtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// ErrNotRecorded is returned by a function created with Replay when it is called with an input
+// which was not recorded.
+var ErrNotRecorded = errors.New("grabtest: no recorded call for input")
+
+// RecordedCall is a call captured by Replay, as stored in testdata/<name>.replay.json.
+type RecordedCall[T any, R any] struct {
+	Input  T      `json:"input"`
+	Output R      `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Replay records the calls made to 'fn' and replays them in later test runs, so that code which calls a real API
+// through grab helpers can be regression-tested against captured responses without network access.
+//
+// Run the tests with the -grabtest.update flag (or the test package's own -update flag) to call 'fn' and write every call to testdata/<name>.replay.json when the test
+// finishes. Otherwise, the returned function doesn't call 'fn': it looks up the recorded output for each input.
+//
+// Parameters:
+//   - t: The test the recording belongs to. Failures to read or write the recording are reported to it.
+//   - name: The name of the recording file, without the testdata directory or extension.
+//   - fn: The real function. It is only called when recording.
+//
+// Returns:
+//   - func(ctx context.Context, in T) (R, error): A function to use in place of 'fn'.
+//
+// Example:
+// getUser := Replay(t, "get_user", client.GetUser)
+//
+//	report, err := buildAccessReport(ctx, getUser, userIDs)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	AssertGolden(t, "access_report", report)
+//
+// Note: Inputs are matched by their JSON encoding. If the same input was recorded more than once,
+// the outputs are replayed in the order they were recorded, and the last output is repeated after that.
+// Recorded errors are replayed with the same message, but not the same type.
+func Replay[T any, R any](t testing.TB, name string, fn func(ctx context.Context, in T) (R, error)) func(ctx context.Context, in T) (R, error) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".replay.json")
+
+	if updating() {
+		return record(t, path, fn)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("grabtest: reading recording (run the tests with -grabtest.update to create it): %s", err)
+	}
+	var calls []RecordedCall[T, R]
+	if err := json.Unmarshal(data, &calls); err != nil {
+		t.Fatalf("grabtest: decoding recording %s: %s", path, err)
+	}
+
+	// group the recorded calls by input, keeping the order they were recorded in
+	byInput := make(map[string][]RecordedCall[T, R])
+	for _, call := range calls {
+		key, err := json.Marshal(call.Input)
+		if err != nil {
+			t.Fatalf("grabtest: encoding recorded input: %s", err)
+		}
+		byInput[string(key)] = append(byInput[string(key)], call)
+	}
+
+	var mu sync.Mutex
+	replayed := make(map[string]int)
+	return func(ctx context.Context, in T) (R, error) {
+		var zero R
+		key, err := json.Marshal(in)
+		if err != nil {
+			return zero, fmt.Errorf("grabtest: encoding input: %w", err)
+		}
+
+		mu.Lock()
+		recorded := byInput[string(key)]
+		i := min(replayed[string(key)], len(recorded)-1)
+		replayed[string(key)]++
+		mu.Unlock()
+
+		if len(recorded) == 0 {
+			return zero, fmt.Errorf("%w %s in %s", ErrNotRecorded, key, path)
+		}
+		call := recorded[i]
+		if call.Error != "" {
+			return call.Output, errors.New(call.Error)
+		}
+		return call.Output, nil
+	}
+}
+
+// record returns a function which calls 'fn' and writes every call to 'path' when the test finishes.
+func record[T any, R any](t testing.TB, path string, fn func(ctx context.Context, in T) (R, error)) func(ctx context.Context, in T) (R, error) {
+	var mu sync.Mutex
+	calls := []RecordedCall[T, R]{}
+
+	t.Cleanup(func() {
+		data, err := canonicalJSON(calls)
+		if err != nil {
+			t.Errorf("grabtest: encoding recording: %s", err)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("grabtest: creating recording directory: %s", err)
+			return
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Errorf("grabtest: writing recording: %s", err)
+		}
+	})
+
+	return func(ctx context.Context, in T) (R, error) {
+		out, err := fn(ctx, in)
+		call := RecordedCall[T, R]{Input: in, Output: out}
+		if err != nil {
+			call.Error = err.Error()
+		}
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+		return out, err
+	}
+}
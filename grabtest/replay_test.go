@@ -0,0 +1,52 @@
+package grabtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/common-fate/grab/grabtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type page struct {
+	Items     []string `json:"items"`
+	NextToken *string  `json:"nextToken"`
+}
+
+// listUsersPage stands in for a real API. When recordings are replayed, it is never called.
+func listUsersPage(calls *int) func(ctx context.Context, token *string) (page, error) {
+	return func(ctx context.Context, token *string) (page, error) {
+		*calls++
+		switch grab.Value(token) {
+		case "":
+			return page{Items: []string{"alice", "bob"}, NextToken: grab.Ptr("2")}, nil
+		case "2":
+			return page{Items: []string{"carol"}}, nil
+		}
+		return page{}, fmt.Errorf("invalid page token %q", grab.Value(token))
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var calls int
+	fetch := grabtest.Replay(t, "list_users", listUsersPage(&calls))
+
+	users, err := grab.AllPages(context.Background(), func(ctx context.Context, token *string) ([]string, *string, error) {
+		p, err := fetch(ctx, token)
+		return p.Items, p.NextToken, err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, users)
+
+	_, err = fetch(context.Background(), grab.Ptr("bad"))
+	assert.EqualError(t, err, `invalid page token "bad"`)
+
+	if !updateFlagSet() {
+		assert.Equal(t, 0, calls, "the real function should not be called when replaying")
+
+		_, err = fetch(context.Background(), grab.Ptr("unknown"))
+		assert.ErrorIs(t, err, grabtest.ErrNotRecorded)
+	}
+}
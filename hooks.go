@@ -0,0 +1,217 @@
+package grab
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// PipelineHooks receives notifications about the progress of a pipeline stage,
+// such as a Map, Filter or AllPages call. Implementations can use these notifications
+// to emit metrics and traces without wrapping every function passed to the stage.
+//
+// Implementations must be safe for concurrent use if they are shared between stages running concurrently.
+// Embed NoopHooks to implement only the notifications you are interested in.
+type PipelineHooks interface {
+	// OnStart is called once before the stage processes any items.
+	OnStart(ctx context.Context, stage string)
+	// OnItem is called after each item is processed. For AllPages, it is called once per page fetched.
+	OnItem(ctx context.Context, stage string, index int)
+	// OnError is called if the stage fails. OnComplete is not called after OnError.
+	OnError(ctx context.Context, stage string, err error)
+	// OnComplete is called once after the stage has finished successfully, with the number of items it produced.
+	OnComplete(ctx context.Context, stage string, count int)
+}
+
+// NoopHooks is a PipelineHooks implementation which ignores all notifications.
+type NoopHooks struct{}
+
+func (NoopHooks) OnStart(ctx context.Context, stage string)               {}
+func (NoopHooks) OnItem(ctx context.Context, stage string, index int)     {}
+func (NoopHooks) OnError(ctx context.Context, stage string, err error)    {}
+func (NoopHooks) OnComplete(ctx context.Context, stage string, count int) {}
+
+// MapWithHooks behaves like Map, and additionally notifies 'hooks' about the progress of the stage.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the hooks.
+//   - hooks: The hooks to notify. If nil, no notifications are sent.
+//   - stage: A name for the stage, passed to the hooks.
+//   - items: A slice of items of type 'T'. These are the items to be transformed.
+//   - fn: A function that takes an item of type 'T' and returns a new item of type 'F'.
+//
+// Returns:
+//   - []F: A slice containing all the transformed items.
+//
+// Example:
+// names := MapWithHooks(ctx, metricsHooks, "extract_names", users, func(u User) string { return u.Name })
+func MapWithHooks[T any, F any](ctx context.Context, hooks PipelineHooks, stage string, items []T, fn func(T) F) []F {
+	hooks = hooksOrNoop(hooks)
+	hooks.OnStart(ctx, stage)
+	var result []F
+	for i, item := range items {
+		result = append(result, fn(item))
+		hooks.OnItem(ctx, stage, i)
+	}
+	hooks.OnComplete(ctx, stage, len(result))
+	return result
+}
+
+// FilterWithHooks behaves like Filter, and additionally notifies 'hooks' about the progress of the stage.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the hooks.
+//   - hooks: The hooks to notify. If nil, no notifications are sent.
+//   - stage: A name for the stage, passed to the hooks.
+//   - items: A slice of items of type 'T'. These are the items to be filtered.
+//   - fn: A predicate function. If 'fn' returns true, the item is included in the result.
+//
+// Returns:
+//   - []T: A slice containing all items that satisfy the predicate 'fn'.
+func FilterWithHooks[T any](ctx context.Context, hooks PipelineHooks, stage string, items []T, fn func(T) bool) []T {
+	hooks = hooksOrNoop(hooks)
+	hooks.OnStart(ctx, stage)
+	var result []T
+	for i, item := range items {
+		if fn(item) {
+			result = append(result, item)
+		}
+		hooks.OnItem(ctx, stage, i)
+	}
+	hooks.OnComplete(ctx, stage, len(result))
+	return result
+}
+
+// AllPagesWithHooks behaves like AllPages, and additionally notifies 'hooks' about the progress of the stage.
+// OnItem is called once for each page fetched, and OnComplete is called with the total number of items.
+//
+// Parameters:
+//   - ctx: A context.Context used for cancellation and timeout control, and passed to the hooks.
+//   - hooks: The hooks to notify. If nil, no notifications are sent.
+//   - stage: A name for the stage, passed to the hooks.
+//   - fetchPage: A function that retrieves a single page, as in AllPages.
+//
+// Returns:
+//   - []T: A slice containing all aggregated items from all pages.
+//   - error: An error if any occurs during the fetching of pages. Unlike AllPages, the error is wrapped to attach
+//     the zero-based number of the page which failed as the "page" field, which can be retrieved with FieldFromErr,
+//     so check for specific errors with errors.Is or errors.As.
+func AllPagesWithHooks[T any, Token comparable](ctx context.Context, hooks PipelineHooks, stage string, fetchPage func(ctx context.Context, nextToken *Token) ([]T, *Token, error)) ([]T, error) {
+	return allPages(ctx, hooks, stage, fetchPage, true)
+}
+
+// allPages implements AllPages and AllPagesWithHooks. If 'pageField' is true, errors from 'fetchPage'
+// are wrapped to attach the page number; otherwise they are returned unchanged.
+func allPages[T any, Token comparable](ctx context.Context, hooks PipelineHooks, stage string, fetchPage func(ctx context.Context, nextToken *Token) ([]T, *Token, error), pageField bool) ([]T, error) {
+	hooks = hooksOrNoop(hooks)
+	hooks.OnStart(ctx, stage)
+
+	var allItems []T
+	var nextToken *Token
+
+	for page := 0; ; page++ {
+		items, newToken, err := fetchPage(ctx, nextToken)
+		if err != nil {
+			if pageField {
+				// attach the page number without changing the error message
+				err = FieldErrorf(Fields{"page": page}, "%w", err)
+			}
+			hooks.OnError(ctx, stage, err)
+			return nil, err
+		}
+
+		allItems = append(allItems, items...)
+		hooks.OnItem(ctx, stage, page)
+
+		if newToken == nil || IsZero(*newToken) {
+			break
+		}
+		nextToken = newToken
+	}
+
+	hooks.OnComplete(ctx, stage, len(allItems))
+	return allItems, nil
+}
+
+func hooksOrNoop(hooks PipelineHooks) PipelineHooks {
+	if hooks == nil {
+		return NoopHooks{}
+	}
+	return hooks
+}
+
+// HookPolicy controls how Hooks.Invoke handles errors returned by hooks.
+type HookPolicy int
+
+const (
+	// HookFailFast stops invoking hooks after the first error, and returns it.
+	HookFailFast HookPolicy = iota
+	// HookCollectErrors invokes every hook, and returns all of their errors joined together.
+	HookCollectErrors
+)
+
+// Hooks is an ordered set of typed callbacks, used to build extension points.
+// Hooks with a higher priority run first, and hooks with the same priority run in the order they were registered.
+// It is safe for concurrent use, and the zero value is an empty set of hooks using HookFailFast.
+//
+// Example:
+// var onGrant Hooks[Grant]
+// onGrant.Register(10, auditGrant)
+// onGrant.Register(0, notifyGrant)
+//
+// err := onGrant.Invoke(ctx, grant) // calls auditGrant, then notifyGrant
+type Hooks[T any] struct {
+	// Policy controls how errors returned by hooks are handled.
+	Policy HookPolicy
+
+	mu    sync.RWMutex
+	hooks []registeredHook[T]
+}
+
+type registeredHook[T any] struct {
+	priority int
+	fn       func(ctx context.Context, v T) error
+}
+
+// Register adds a hook with the given priority.
+func (h *Hooks[T]) Register(priority int, fn func(ctx context.Context, v T) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// copy rather than sorting in place, as Invoke may be iterating over the current slice
+	hooks := append(Freeze(h.hooks), registeredHook[T]{priority: priority, fn: fn})
+	slices.SortStableFunc(hooks, func(a, b registeredHook[T]) int {
+		return cmp.Compare(b.priority, a.priority)
+	})
+	h.hooks = hooks
+}
+
+// Len returns the number of registered hooks.
+func (h *Hooks[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.hooks)
+}
+
+// Invoke calls the registered hooks in order with the provided value, handling errors according to the Policy.
+// If the context is cancelled, no further hooks are called and the context error is returned.
+func (h *Hooks[T]) Invoke(ctx context.Context, v T) error {
+	h.mu.RLock()
+	hooks := h.hooks
+	h.mu.RUnlock()
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := hook.fn(ctx, v); err != nil {
+			if h.Policy == HookFailFast {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,163 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHooks struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHooks) record(event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *recordingHooks) OnStart(ctx context.Context, stage string) {
+	h.record(fmt.Sprintf("start %s", stage))
+}
+func (h *recordingHooks) OnItem(ctx context.Context, stage string, index int) {
+	h.record(fmt.Sprintf("item %s %d", stage, index))
+}
+func (h *recordingHooks) OnError(ctx context.Context, stage string, err error) {
+	h.record(fmt.Sprintf("error %s %s", stage, err))
+}
+func (h *recordingHooks) OnComplete(ctx context.Context, stage string, count int) {
+	h.record(fmt.Sprintf("complete %s %d", stage, count))
+}
+
+func TestMapWithHooks(t *testing.T) {
+	hooks := &recordingHooks{}
+	got := grab.MapWithHooks(context.Background(), hooks, "double", []int{1, 2}, func(i int) int { return i * 2 })
+	assert.Equal(t, []int{2, 4}, got)
+	assert.Equal(t, []string{"start double", "item double 0", "item double 1", "complete double 2"}, hooks.events)
+}
+
+func TestFilterWithHooks(t *testing.T) {
+	hooks := &recordingHooks{}
+	got := grab.FilterWithHooks(context.Background(), hooks, "even", []int{1, 2, 3}, func(i int) bool { return i%2 == 0 })
+	assert.Equal(t, []int{2}, got)
+	assert.Equal(t, []string{"start even", "item even 0", "item even 1", "item even 2", "complete even 1"}, hooks.events)
+}
+
+func TestAllPagesWithHooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockErr    error
+		want       []string
+		wantEvents []string
+	}{
+		{
+			name:       "two pages",
+			want:       []string{"a", "b", "c"},
+			wantEvents: []string{"start list", "item list 0", "item list 1", "complete list 3"},
+		},
+		{
+			name:       "error",
+			mockErr:    errors.New("mock"),
+			wantEvents: []string{"start list", "error list mock"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks := &recordingHooks{}
+			pages := [][]string{{"a", "b"}, {"c"}}
+			got, err := grab.AllPagesWithHooks(context.Background(), hooks, "list", func(ctx context.Context, nextToken *int) ([]string, *int, error) {
+				if tt.mockErr != nil {
+					return nil, nil, tt.mockErr
+				}
+				next := grab.Value(nextToken)
+				return pages[next], grab.If(len(pages)-1 == next, nil, grab.Ptr(next+1)), nil
+			})
+			if tt.mockErr != nil {
+				assert.EqualError(t, err, tt.mockErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantEvents, hooks.events)
+		})
+	}
+}
+
+func TestHooks(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	tests := []struct {
+		name       string
+		policy     grab.HookPolicy
+		errs       map[string]error
+		wantCalled []string
+		wantErrs   []error
+	}{
+		{
+			name:       "runs in priority order",
+			wantCalled: []string{"high", "first", "second", "low"},
+		},
+		{
+			name:       "fail fast",
+			policy:     grab.HookFailFast,
+			errs:       map[string]error{"first": errA, "low": errB},
+			wantCalled: []string{"high", "first"},
+			wantErrs:   []error{errA},
+		},
+		{
+			name:       "collect errors",
+			policy:     grab.HookCollectErrors,
+			errs:       map[string]error{"first": errA, "low": errB},
+			wantCalled: []string{"high", "first", "second", "low"},
+			wantErrs:   []error{errA, errB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called []string
+			hook := func(name string) func(ctx context.Context, v int) error {
+				return func(ctx context.Context, v int) error {
+					called = append(called, name)
+					return tt.errs[name]
+				}
+			}
+
+			h := grab.Hooks[int]{Policy: tt.policy}
+			h.Register(0, hook("first"))
+			h.Register(-5, hook("low"))
+			h.Register(0, hook("second"))
+			h.Register(10, hook("high"))
+			assert.Equal(t, 4, h.Len())
+
+			err := h.Invoke(context.Background(), 1)
+			assert.Equal(t, tt.wantCalled, called)
+			if tt.wantErrs == nil {
+				assert.NoError(t, err)
+			}
+			for _, wantErr := range tt.wantErrs {
+				assert.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestHooksCancelled(t *testing.T) {
+	var h grab.Hooks[int]
+	called := false
+	h.Register(0, func(ctx context.Context, v int) error {
+		called = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, h.Invoke(ctx, 1), context.Canceled)
+	assert.False(t, called)
+}
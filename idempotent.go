@@ -0,0 +1,235 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Idempotent executes a function at most once per key and replays the stored result
+// (both the value and the error) for subsequent calls made within a TTL.
+// It is a generic type that works with any comparable key type 'K' and any result type 'V'.
+//
+// Concurrent calls for a key that is currently executing wait for the in-flight call to
+// finish and receive its result, rather than executing the function a second time.
+//
+// Example:
+// idem := NewIdempotent[string, Receipt](10 * time.Minute)
+//
+//	receipt, err := idem.Do(ctx, event.ID, func(ctx context.Context) (Receipt, error) {
+//	    return processWebhook(ctx, event)
+//	})
+//
+// Note: This type is useful for webhook handlers and other at-least-once delivery consumers,
+// where the same event may be delivered several times and should only be processed once.
+// Use NewIdempotentWithStore to share successful results between processes and across restarts.
+type Idempotent[K comparable, V any] struct {
+	ttl       time.Duration
+	clock     Clock
+	store     KVStore[K, IdempotentResult[V]]
+	mu        sync.Mutex
+	entries   map[K]*idempotentEntry[V]
+	lastSweep time.Time
+}
+
+// IdempotentResult is a successful result persisted to a KVStore by an Idempotent created with NewIdempotentWithStore.
+type IdempotentResult[V any] struct {
+	// Value is the value returned by the function.
+	Value V
+	// Expires is when the result stops being replayed. It is the zero time if the result never expires.
+	Expires time.Time
+}
+
+var errIdempotentPanic = errors.New("grab: idempotent function panicked")
+
+type idempotentEntry[V any] struct {
+	done    chan struct{}
+	value   V
+	err     error
+	expires time.Time
+}
+
+// NewIdempotent creates an Idempotent which replays results for the provided TTL.
+//
+// Parameters:
+//   - ttl: How long a completed result is replayed for. A TTL of zero or less means results never expire.
+//   - opts: Optional settings. WithClock sets the Clock used to expire results.
+//
+// Returns:
+//   - *Idempotent[K, V]: A new Idempotent with no stored results.
+func NewIdempotent[K comparable, V any](ttl time.Duration, opts ...Option[Config]) *Idempotent[K, V] {
+	cfg := newConfig(opts)
+	return &Idempotent[K, V]{
+		ttl:     ttl,
+		clock:   cfg.Clock,
+		entries: make(map[K]*idempotentEntry[V]),
+	}
+}
+
+// NewIdempotentWithStore creates an Idempotent which also persists successful results to a KVStore,
+// so that they are replayed by other processes sharing the store, and after a restart.
+// Errors are only replayed by the process which saw them, so that a failed operation can be retried elsewhere.
+//
+// Parameters:
+//   - ttl: How long a completed result is replayed for. A TTL of zero or less means results never expire.
+//   - store: The store which persists successful results.
+//   - opts: Optional settings. WithClock sets the Clock used to expire results.
+//
+// Returns:
+//   - *Idempotent[K, V]: A new Idempotent backed by 'store'.
+func NewIdempotentWithStore[K comparable, V any](ttl time.Duration, store KVStore[K, IdempotentResult[V]], opts ...Option[Config]) *Idempotent[K, V] {
+	i := NewIdempotent[K, V](ttl, opts...)
+	i.store = store
+	return i
+}
+
+// Do executes 'fn' for the given key if it has not been executed within the TTL,
+// otherwise it returns the stored result of the previous execution.
+//
+// Parameters:
+//   - ctx: A context.Context passed to 'fn'. If the context is cancelled while waiting on an
+//     in-flight call for the same key, Do returns the context error.
+//   - key: The idempotency key identifying the operation.
+//   - fn: The function to execute.
+//
+// Returns:
+//   - V: The value returned by 'fn', either from this call or replayed from a previous call.
+//   - error: The error returned by 'fn', either from this call or replayed from a previous call,
+//     or an error from the store if one is used.
+//
+// Note: If 'fn' succeeds but its result can't be saved to the store, Do returns the value together with the
+// store error, and the result is not kept in memory either, so the next call executes 'fn' again.
+// Callers waiting on the same in-flight call receive the same value and error.
+func (i *Idempotent[K, V]) Do(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (V, error) {
+	i.mu.Lock()
+	if entry, ok := i.entries[key]; ok {
+		select {
+		case <-entry.done:
+			if i.ttl <= 0 || i.clock.Now().Before(entry.expires) {
+				i.mu.Unlock()
+				return entry.value, entry.err
+			}
+		default:
+			// the call is still in flight, so wait for it to complete
+			i.mu.Unlock()
+			select {
+			case <-entry.done:
+				return entry.value, entry.err
+			case <-ctx.Done():
+				var zero V
+				return zero, ctx.Err()
+			}
+		}
+	}
+
+	i.sweep()
+	entry := &idempotentEntry[V]{done: make(chan struct{})}
+	i.entries[key] = entry
+	i.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			// fn panicked, so release any waiters without storing a result
+			i.forget(key, entry)
+			entry.err = errIdempotentPanic
+			close(entry.done)
+		}
+	}()
+
+	stored, found, err := i.load(ctx, key)
+	if err != nil {
+		// don't replay store errors, so that the next call tries the store again
+		i.forget(key, entry)
+		entry.err = err
+		completed = true
+		close(entry.done)
+		var zero V
+		return zero, err
+	}
+
+	if found {
+		entry.value, entry.expires = stored.Value, stored.Expires
+	} else {
+		entry.value, entry.err = fn(ctx)
+		entry.expires = i.clock.Now().Add(i.ttl)
+		if entry.err == nil {
+			if err := i.save(ctx, key, entry); err != nil {
+				// the result wasn't persisted, so don't replay it as a success to a caller retrying after this error
+				i.forget(key, entry)
+				entry.err = fmt.Errorf("storing idempotent result: %w", err)
+			}
+		}
+	}
+	completed = true
+	close(entry.done)
+	return entry.value, entry.err
+}
+
+// load returns the unexpired result persisted for the key, if a store is used.
+func (i *Idempotent[K, V]) load(ctx context.Context, key K) (IdempotentResult[V], bool, error) {
+	if i.store == nil {
+		return IdempotentResult[V]{}, false, nil
+	}
+	result, ok, err := i.store.Get(ctx, key)
+	if err != nil || !ok {
+		return IdempotentResult[V]{}, false, err
+	}
+	if !result.Expires.IsZero() && !i.clock.Now().Before(result.Expires) {
+		return IdempotentResult[V]{}, false, nil
+	}
+	return result, true, nil
+}
+
+// save persists a successful result, if a store is used.
+func (i *Idempotent[K, V]) save(ctx context.Context, key K, entry *idempotentEntry[V]) error {
+	if i.store == nil {
+		return nil
+	}
+	result := IdempotentResult[V]{Value: entry.value}
+	if i.ttl > 0 {
+		result.Expires = entry.expires
+	}
+	return i.store.Put(ctx, key, result)
+}
+
+// sweep removes expired results, at most once per TTL, so that the entries map doesn't grow without bound.
+// The caller must hold the lock.
+func (i *Idempotent[K, V]) sweep() {
+	if i.ttl <= 0 {
+		return
+	}
+	now := i.clock.Now()
+	if now.Sub(i.lastSweep) < i.ttl {
+		return
+	}
+	i.lastSweep = now
+	for key, entry := range i.entries {
+		select {
+		case <-entry.done:
+			if !now.Before(entry.expires) {
+				delete(i.entries, key)
+			}
+		default:
+		}
+	}
+}
+
+// Forget removes any stored result for the given key from memory, so that the next call to Do executes 'fn' again.
+// It doesn't remove a result persisted to a store: delete the key from the store as well to forget it everywhere.
+func (i *Idempotent[K, V]) Forget(key K) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.entries, key)
+}
+
+// forget removes the entry for the key, unless it has already been replaced by a newer call after Forget.
+func (i *Idempotent[K, V]) forget(key K, entry *idempotentEntry[V]) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.entries[key] == entry {
+		delete(i.entries, key)
+	}
+}
@@ -0,0 +1,242 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotent(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		advance   time.Duration
+		result    string
+		err       error
+		wantCalls int32
+	}{
+		{
+			name:      "result is replayed",
+			ttl:       time.Minute,
+			result:    "ok",
+			wantCalls: 1,
+		},
+		{
+			name:      "error is replayed",
+			ttl:       time.Minute,
+			err:       errors.New("mock"),
+			wantCalls: 1,
+		},
+		{
+			name:      "result expires after ttl",
+			ttl:       time.Minute,
+			advance:   time.Minute,
+			result:    "ok",
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := grab.NewFakeClock(epoch)
+			idem := grab.NewIdempotent[string, string](tt.ttl, grab.WithClock(clock))
+			var calls int32
+			fn := func(ctx context.Context) (string, error) {
+				atomic.AddInt32(&calls, 1)
+				return tt.result, tt.err
+			}
+
+			for i := 0; i < 2; i++ {
+				got, err := idem.Do(context.Background(), "key", fn)
+				if tt.err != nil {
+					assert.EqualError(t, err, tt.err.Error())
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, tt.result, got)
+				clock.Advance(tt.advance)
+			}
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestIdempotentConcurrent(t *testing.T) {
+	idem := grab.NewIdempotent[string, int](time.Minute)
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = idem.Do(context.Background(), "key", func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return 42, nil
+			})
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+}
+
+func TestIdempotentWithStore(t *testing.T) {
+	ctx := context.Background()
+	clock := grab.NewFakeClock(epoch)
+	store := grab.NewMemoryKVStore[string, grab.IdempotentResult[string]]()
+
+	var calls int32
+	fn := func(result string, err error) func(ctx context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			return result, err
+		}
+	}
+
+	first := grab.NewIdempotentWithStore(time.Minute, store, grab.WithClock(clock))
+	got, err := first.Do(ctx, "ok", fn("receipt", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt", got)
+
+	_, err = first.Do(ctx, "failed", fn("", errors.New("mock")))
+	assert.Error(t, err)
+
+	// a second instance, such as another process, shares successful results through the store
+	second := grab.NewIdempotentWithStore(time.Minute, store, grab.WithClock(clock))
+	got, err = second.Do(ctx, "ok", fn("other", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt", got)
+
+	got, err = second.Do(ctx, "failed", fn("retried", nil))
+	assert.NoError(t, err, "errors should not be persisted")
+	assert.Equal(t, "retried", got)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// persisted results expire after the ttl
+	clock.Advance(time.Minute)
+	third := grab.NewIdempotentWithStore(time.Minute, store, grab.WithClock(clock))
+	got, err = third.Do(ctx, "ok", fn("fresh", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", got)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestIdempotentStoreError(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	idem := grab.NewIdempotentWithStore[string, string](time.Minute, failingStore[string, grab.IdempotentResult[string]]{err: errStore})
+
+	var calls int32
+	fn := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "ok", nil
+	}
+	for range 2 {
+		_, err := idem.Do(context.Background(), "key", fn)
+		assert.ErrorIs(t, err, errStore)
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "fn should not run if the store can't be read")
+}
+
+// putFailingStore is a KVStore which reads from the embedded store, but fails to write while err is set.
+type putFailingStore[K comparable, V any] struct {
+	grab.KVStore[K, V]
+	err *error
+}
+
+func (s putFailingStore[K, V]) Put(ctx context.Context, key K, value V) error {
+	if *s.err != nil {
+		return *s.err
+	}
+	return s.KVStore.Put(ctx, key, value)
+}
+
+func TestIdempotentSaveError(t *testing.T) {
+	ctx := context.Background()
+	errStore := errors.New("store unavailable")
+	putErr := errStore
+	store := putFailingStore[string, grab.IdempotentResult[string]]{
+		KVStore: grab.NewMemoryKVStore[string, grab.IdempotentResult[string]](),
+		err:     &putErr,
+	}
+	idem := grab.NewIdempotentWithStore[string, string](time.Minute, store)
+
+	var calls int32
+	fn := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "receipt", nil
+	}
+
+	got, err := idem.Do(ctx, "key", fn)
+	assert.ErrorIs(t, err, errStore)
+	assert.Equal(t, "receipt", got)
+
+	// the unsaved result isn't replayed as a success, so a retry executes fn again and saves the result
+	putErr = nil
+	got, err = idem.Do(ctx, "key", fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt", got)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, ok, err := store.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestIdempotentPanicAfterForget(t *testing.T) {
+	ctx := context.Background()
+	idem := grab.NewIdempotent[string, string](time.Minute)
+
+	// the first call panics after the key was forgotten and a second call started
+	started, release := make(chan struct{}), make(chan struct{})
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() { panicked <- recover() }()
+		_, _ = idem.Do(ctx, "key", func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	receive(t, started)
+	idem.Forget("key")
+
+	secondStarted, secondRelease := make(chan struct{}), make(chan struct{})
+	second := make(chan string, 1)
+	go func() {
+		got, _ := idem.Do(ctx, "key", func(ctx context.Context) (string, error) {
+			close(secondStarted)
+			<-secondRelease
+			return "second", nil
+		})
+		second <- got
+	}()
+	receive(t, secondStarted)
+
+	close(release)
+	assert.Equal(t, "boom", receive(t, panicked))
+
+	// the second call's in-flight entry is kept, so a third call waits for it rather than executing fn
+	third := make(chan string, 1)
+	go func() {
+		got, _ := idem.Do(ctx, "key", func(ctx context.Context) (string, error) {
+			return "third", nil
+		})
+		third <- got
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(secondRelease)
+	assert.Equal(t, "second", receive(t, second))
+	assert.Equal(t, "second", receive(t, third))
+}
@@ -0,0 +1,145 @@
+package grab
+
+import "cmp"
+
+// Interval is a closed interval [Start, End] with an associated value.
+type Interval[T cmp.Ordered, V any] struct {
+	Start T
+	End   T
+	Value V
+}
+
+// IntervalTree stores intervals and efficiently finds those containing a point or overlapping a range.
+// Queries take O(log n + k) time for k results. The zero value is an empty IntervalTree.
+// It is not safe for concurrent use while it is being modified.
+//
+// Example:
+// var grants IntervalTree[int64, Grant]
+// grants.Insert(g.Start.Unix(), g.End.Unix(), g)
+//
+// overlapping := grants.Overlapping(req.Start.Unix(), req.End.Unix())
+//
+// Note: This type is useful for checking whether a proposed access window overlaps any existing grants,
+// without scanning every grant.
+type IntervalTree[T cmp.Ordered, V any] struct {
+	root *intervalNode[T, V]
+	size int
+	rng  uint64
+}
+
+// intervalNode is a node of a treap ordered by interval start, augmented with the maximum end in its subtree.
+type intervalNode[T cmp.Ordered, V any] struct {
+	interval    Interval[T, V]
+	maxEnd      T
+	priority    uint64
+	left, right *intervalNode[T, V]
+}
+
+// Insert adds the closed interval [start, end] with a value. If start is after end, they are swapped.
+func (t *IntervalTree[T, V]) Insert(start, end T, value V) {
+	if end < start {
+		start, end = end, start
+	}
+	t.size++
+	n := &intervalNode[T, V]{
+		interval: Interval[T, V]{Start: start, End: end, Value: value},
+		maxEnd:   end,
+		priority: t.nextPriority(),
+	}
+	t.root = t.root.insert(n)
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[T, V]) Len() int {
+	return t.size
+}
+
+// Stab returns the intervals which contain the point, ordered by start.
+func (t *IntervalTree[T, V]) Stab(point T) []Interval[T, V] {
+	return t.Overlapping(point, point)
+}
+
+// Overlapping returns the intervals which overlap the closed interval [start, end], ordered by start.
+func (t *IntervalTree[T, V]) Overlapping(start, end T) []Interval[T, V] {
+	if end < start {
+		start, end = end, start
+	}
+	var result []Interval[T, V]
+	t.root.overlapping(start, end, &result)
+	return result
+}
+
+// nextPriority returns a pseudo-random treap priority using xorshift, which keeps the tree balanced
+// in expectation without depending on a shared random source.
+func (t *IntervalTree[T, V]) nextPriority() uint64 {
+	if t.rng == 0 {
+		t.rng = 0x9E3779B97F4A7C15
+	}
+	t.rng ^= t.rng << 13
+	t.rng ^= t.rng >> 7
+	t.rng ^= t.rng << 17
+	return t.rng
+}
+
+func (n *intervalNode[T, V]) insert(new *intervalNode[T, V]) *intervalNode[T, V] {
+	if n == nil {
+		return new
+	}
+	if new.interval.Start < n.interval.Start {
+		n.left = n.left.insert(new)
+		if n.left.priority > n.priority {
+			n = n.rotateRight()
+		}
+	} else {
+		n.right = n.right.insert(new)
+		if n.right.priority > n.priority {
+			n = n.rotateLeft()
+		}
+	}
+	n.update()
+	return n
+}
+
+func (n *intervalNode[T, V]) rotateRight() *intervalNode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *intervalNode[T, V]) rotateLeft() *intervalNode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *intervalNode[T, V]) update() {
+	n.maxEnd = n.interval.End
+	if n.left != nil {
+		n.maxEnd = max(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = max(n.maxEnd, n.right.maxEnd)
+	}
+}
+
+func (n *intervalNode[T, V]) overlapping(start, end T, result *[]Interval[T, V]) {
+	// no interval in this subtree ends at or after the query start
+	if n == nil || n.maxEnd < start {
+		return
+	}
+	n.left.overlapping(start, end, result)
+	// intervals in the right subtree start at or after this one, so stop if this one starts after the query
+	if n.interval.Start > end {
+		return
+	}
+	if n.interval.End >= start {
+		*result = append(*result, n.interval)
+	}
+	n.right.overlapping(start, end, result)
+}
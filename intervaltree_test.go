@@ -0,0 +1,74 @@
+package grab_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalTree(t *testing.T) {
+	var tree grab.IntervalTree[int, string]
+	tree.Insert(1, 5, "a")
+	tree.Insert(10, 20, "b")
+	tree.Insert(4, 12, "c")
+	tree.Insert(30, 25, "d") // reversed bounds are swapped
+	assert.Equal(t, 4, tree.Len())
+
+	values := func(intervals []grab.Interval[int, string]) []string {
+		return grab.Map(intervals, func(i grab.Interval[int, string]) string { return i.Value })
+	}
+
+	tests := []struct {
+		name       string
+		start, end int
+		want       []string
+	}{
+		{
+			name:  "point inside two intervals",
+			start: 4, end: 4,
+			want: []string{"a", "c"},
+		},
+		{
+			name:  "closed bounds",
+			start: 20, end: 25,
+			want: []string{"b", "d"},
+		},
+		{
+			name:  "no overlap",
+			start: 21, end: 24,
+			want: nil,
+		},
+		{
+			name:  "covers everything",
+			start: 0, end: 100,
+			want: []string{"a", "c", "b", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, values(tree.Overlapping(tt.start, tt.end)))
+		})
+	}
+	assert.Equal(t, []string{"b"}, values(tree.Stab(15)))
+}
+
+func TestIntervalTreeMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var tree grab.IntervalTree[int, int]
+	var all []grab.Interval[int, int]
+	for i := 0; i < 500; i++ {
+		start := r.Intn(1000)
+		end := start + r.Intn(50)
+		tree.Insert(start, end, i)
+		all = append(all, grab.Interval[int, int]{Start: start, End: end, Value: i})
+	}
+
+	for q := 0; q < 100; q++ {
+		start := r.Intn(1000)
+		end := start + r.Intn(20)
+		want := grab.Filter(all, func(i grab.Interval[int, int]) bool { return i.Start <= end && i.End >= start })
+		assert.ElementsMatch(t, want, tree.Overlapping(start, end))
+	}
+}
@@ -0,0 +1,128 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// HTTPStatusError is returned when an HTTP request made by a grab helper receives a response with a non-2xx status code.
+type HTTPStatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Header contains the response headers.
+	Header http.Header
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// JSONPager fetches every page from a paginated HTTP JSON API where each page is requested by
+// repeating the same request with a page token, such as "GET /users?pageToken=abc".
+//
+// Responses are decoded as an Envelope by default, and a Decode function can be provided for other shapes.
+//
+// Example:
+//
+//	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/users", nil)
+//	pager := JSONPager[User]{
+//	    Request:    req,
+//	    TokenParam: "pageToken",
+//	    Decode: func(body io.Reader) ([]User, string, error) {
+//	        var resp struct {
+//	            Users     []User `json:"users"`
+//	            NextToken string `json:"nextToken"`
+//	        }
+//	        err := json.NewDecoder(body).Decode(&resp)
+//	        return resp.Users, resp.NextToken, err
+//	    },
+//	}
+//	users, err := pager.AllPages(ctx)
+type JSONPager[T any] struct {
+	// Client is used to send requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Request is the request for the first page. It is cloned for each page, so it is not modified.
+	// It must not have a body.
+	Request *http.Request
+	// TokenParam is the name of the query parameter the page token is sent in.
+	// Defaults to "pageToken" if neither TokenParam nor TokenHeader is set.
+	TokenParam string
+	// TokenHeader is the name of the header the page token is sent in, for APIs which don't use a query parameter.
+	TokenHeader string
+	// Decode reads a response body, returning the items on the page and the token for the next page.
+	// An empty token indicates that there are no more pages. Defaults to DecodeEnvelope.
+	Decode func(body io.Reader) (items []T, nextToken string, err error)
+}
+
+// AllPages fetches every page and returns all of the items, as in the package-level AllPages function.
+// A non-2xx response returns an *HTTPStatusError.
+func (p *JSONPager[T]) AllPages(ctx context.Context) ([]T, error) {
+	return AllPages(ctx, p.fetchPage)
+}
+
+// Seq returns a sequence of the items on every page. Pages are fetched lazily as the sequence is iterated,
+// so stopping iteration early avoids fetching the remaining pages.
+// If fetching a page fails, the error is yielded with the zero value of type 'T' and iteration stops.
+func (p *JSONPager[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var token *string
+		for {
+			items, next, err := p.fetchPage(ctx, token)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if next == nil || *next == "" {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+func (p *JSONPager[T]) fetchPage(ctx context.Context, token *string) ([]T, *string, error) {
+	req := p.Request.Clone(ctx)
+	if token != nil {
+		if p.TokenHeader != "" {
+			req.Header.Set(p.TokenHeader, *token)
+		}
+		if p.TokenParam != "" || p.TokenHeader == "" {
+			q := req.URL.Query()
+			q.Set(FirstNonZero(p.TokenParam, "pageToken"), *token)
+			req.URL.RawQuery = q.Encode()
+		}
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, &HTTPStatusError{StatusCode: resp.StatusCode, Header: resp.Header}
+	}
+
+	decode := p.Decode
+	if decode == nil {
+		decode = DecodeEnvelope[T]
+	}
+	items, next, err := decode(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding page: %w", err)
+	}
+	return items, &next, nil
+}
@@ -0,0 +1,119 @@
+package grab_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type pagedUsers struct {
+	Users     []string `json:"users"`
+	NextToken string   `json:"nextToken"`
+}
+
+func decodePagedUsers(body io.Reader) ([]string, string, error) {
+	var resp pagedUsers
+	err := json.NewDecoder(body).Decode(&resp)
+	return resp.Users, resp.NextToken, err
+}
+
+// newUsersServer serves three pages of users, reading the page token with the provided function.
+func newUsersServer(t *testing.T, token func(r *http.Request) string) *httptest.Server {
+	pages := map[string]pagedUsers{
+		"":  {Users: []string{"alice", "bob"}, NextToken: "2"},
+		"2": {Users: []string{"carol"}, NextToken: "3"},
+		"3": {Users: []string{"dave"}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[token(r)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestJSONPager(t *testing.T) {
+	tests := []struct {
+		name  string
+		pager grab.JSONPager[string]
+		token func(r *http.Request) string
+	}{
+		{
+			name:  "default query parameter",
+			pager: grab.JSONPager[string]{Decode: decodePagedUsers},
+			token: func(r *http.Request) string { return r.URL.Query().Get("pageToken") },
+		},
+		{
+			name:  "custom query parameter",
+			pager: grab.JSONPager[string]{TokenParam: "cursor", Decode: decodePagedUsers},
+			token: func(r *http.Request) string { return r.URL.Query().Get("cursor") },
+		},
+		{
+			name:  "header",
+			pager: grab.JSONPager[string]{TokenHeader: "X-Page-Token", Decode: decodePagedUsers},
+			token: func(r *http.Request) string { return r.Header.Get("X-Page-Token") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newUsersServer(t, tt.token)
+			req, err := http.NewRequest(http.MethodGet, srv.URL+"/users?limit=2", nil)
+			assert.NoError(t, err)
+			tt.pager.Request = req
+
+			got, err := tt.pager.AllPages(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"alice", "bob", "carol", "dave"}, got)
+			assert.Equal(t, "limit=2", req.URL.RawQuery, "base request should not be modified")
+		})
+	}
+}
+
+func TestJSONPagerSeq(t *testing.T) {
+	var requests int
+	srv := newUsersServer(t, func(r *http.Request) string {
+		requests++
+		return r.URL.Query().Get("pageToken")
+	})
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	assert.NoError(t, err)
+	pager := grab.JSONPager[string]{Request: req, Decode: decodePagedUsers}
+
+	var got []string
+	for user, err := range pager.Seq(context.Background()) {
+		assert.NoError(t, err)
+		got = append(got, user)
+		if user == "carol" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"alice", "bob", "carol"}, got)
+	assert.Equal(t, 2, requests, "remaining pages should not be fetched")
+}
+
+func TestJSONPagerStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	assert.NoError(t, err)
+	pager := grab.JSONPager[string]{Request: req, Decode: decodePagedUsers}
+
+	_, err = pager.AllPages(context.Background())
+	var statusErr *grab.HTTPStatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	}
+}
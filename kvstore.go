@@ -0,0 +1,67 @@
+package grab
+
+import (
+	"context"
+	"sync"
+)
+
+// KVStore is a minimal key-value store, used by stateful helpers such as Ledger and Idempotent to persist
+// their state. Implement it to back those helpers with a durable store such as DynamoDB or Redis,
+// and use MemoryKVStore in tests.
+//
+// Implementations must be safe for concurrent use.
+type KVStore[K comparable, V any] interface {
+	// Get returns the value stored for the key, and false if there is none.
+	Get(ctx context.Context, key K) (V, bool, error)
+	// Put stores a value for the key, replacing any existing value.
+	Put(ctx context.Context, key K, value V) error
+	// Delete removes the value stored for the key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key K) error
+	// List returns every stored key and value.
+	List(ctx context.Context) (map[K]V, error)
+}
+
+// MemoryKVStore is an in-memory KVStore. It is safe for concurrent use, and the zero value is an empty store.
+type MemoryKVStore[K comparable, V any] struct {
+	mu     sync.RWMutex
+	values map[K]V
+}
+
+// NewMemoryKVStore creates an empty MemoryKVStore.
+func NewMemoryKVStore[K comparable, V any]() *MemoryKVStore[K, V] {
+	return &MemoryKVStore[K, V]{}
+}
+
+// Get returns the value stored for the key, and false if there is none.
+func (m *MemoryKVStore[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.values[key]
+	return v, ok, nil
+}
+
+// Put stores a value for the key, replacing any existing value.
+func (m *MemoryKVStore[K, V]) Put(ctx context.Context, key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values == nil {
+		m.values = make(map[K]V)
+	}
+	m.values[key] = value
+	return nil
+}
+
+// Delete removes the value stored for the key.
+func (m *MemoryKVStore[K, V]) Delete(ctx context.Context, key K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	return nil
+}
+
+// List returns a copy of every stored key and value.
+func (m *MemoryKVStore[K, V]) List(ctx context.Context) (map[K]V, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return FreezeMap(m.values), nil
+}
@@ -0,0 +1,58 @@
+package grab_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a KVStore whose operations always fail.
+type failingStore[K comparable, V any] struct{ err error }
+
+func (s failingStore[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+	var zero V
+	return zero, false, s.err
+}
+func (s failingStore[K, V]) Put(ctx context.Context, key K, value V) error { return s.err }
+func (s failingStore[K, V]) Delete(ctx context.Context, key K) error       { return s.err }
+func (s failingStore[K, V]) List(ctx context.Context) (map[K]V, error)     { return nil, s.err }
+
+func TestMemoryKVStore(t *testing.T) {
+	ctx := context.Background()
+	var store grab.KVStore[string, int] = grab.NewMemoryKVStore[string, int]()
+
+	_, ok, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, store.Put(ctx, "a", 1))
+	assert.NoError(t, store.Put(ctx, "b", 2))
+	assert.NoError(t, store.Put(ctx, "a", 3))
+
+	v, ok, err := store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	assert.NoError(t, store.Delete(ctx, "b"))
+	assert.NoError(t, store.Delete(ctx, "missing"))
+
+	all, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 3}, all)
+
+	// List returns a copy
+	all["c"] = 4
+	all, _ = store.List(ctx)
+	assert.Equal(t, map[string]int{"a": 3}, all)
+}
+
+func TestMemoryKVStoreZeroValue(t *testing.T) {
+	var store grab.MemoryKVStore[string, int]
+	assert.NoError(t, store.Put(context.Background(), "a", 1))
+	v, ok, _ := store.Get(context.Background(), "a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
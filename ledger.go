@@ -0,0 +1,111 @@
+package grab
+
+import (
+	"context"
+	"time"
+)
+
+// Ledger records which items have been processed, so that a job which is interrupted and restarted
+// can skip the items it has already handled.
+//
+// Processing is exactly-once-ish: an item is marked as processed only after it has been handled successfully,
+// so an item may be processed again if the process stops between handling it and marking it.
+// Handlers should therefore still be idempotent.
+//
+// Example:
+// ledger := NewLedger[string](dynamoStore)
+//
+//	for _, event := range events {
+//	    err := ledger.Process(ctx, event.ID, func(ctx context.Context) error {
+//	        return handle(ctx, event)
+//	    })
+//	    if err != nil {
+//	        return err
+//	    }
+//	}
+type Ledger[K comparable] struct {
+	store KVStore[K, time.Time]
+	clock Clock
+}
+
+// NewLedger creates a Ledger backed by the given store, which maps each processed key to the time it was processed.
+//
+// Parameters:
+//   - store: The store which persists processed keys. If nil, a MemoryKVStore is used.
+//   - opts: Optional settings. WithClock sets the Clock used to record when keys were processed.
+//
+// Returns:
+//   - *Ledger[K]: A new Ledger.
+func NewLedger[K comparable](store KVStore[K, time.Time], opts ...Option[Config]) *Ledger[K] {
+	cfg := newConfig(opts)
+	if store == nil {
+		store = NewMemoryKVStore[K, time.Time]()
+	}
+	return &Ledger[K]{store: store, clock: cfg.Clock}
+}
+
+// MarkProcessed records that the keys have been processed.
+func (l *Ledger[K]) MarkProcessed(ctx context.Context, keys ...K) error {
+	now := l.clock.Now()
+	for _, key := range keys {
+		if err := l.store.Put(ctx, key, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsProcessed reports whether the key has been recorded as processed.
+func (l *Ledger[K]) IsProcessed(ctx context.Context, key K) (bool, error) {
+	_, ok, err := l.store.Get(ctx, key)
+	return ok, err
+}
+
+// Process calls 'fn' unless the key has already been processed, and marks the key as processed if 'fn' succeeds.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the store and to 'fn'.
+//   - key: The key identifying the item being processed.
+//   - fn: The function which processes the item.
+//
+// Returns:
+//   - error: The error returned by 'fn' or by the store. It returns nil without calling 'fn' if the key was already processed.
+func (l *Ledger[K]) Process(ctx context.Context, key K, fn func(ctx context.Context) error) error {
+	done, err := l.IsProcessed(ctx, key)
+	if err != nil || done {
+		return err
+	}
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return l.MarkProcessed(ctx, key)
+}
+
+// Unprocessed returns the items whose keys have not been processed, in their original order.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the store.
+//   - l: The Ledger to check.
+//   - items: The items to check.
+//   - keyFn: A function that returns the key identifying an item.
+//
+// Returns:
+//   - []T: The items which have not been processed.
+//   - error: The first error returned by the store.
+//
+// Example:
+// page, next, err := listEvents(ctx, token)
+// page, err = Unprocessed(ctx, ledger, page, func(e Event) string { return e.ID })
+func Unprocessed[T any, K comparable](ctx context.Context, l *Ledger[K], items []T, keyFn func(T) K) ([]T, error) {
+	var result []T
+	for _, item := range items {
+		done, err := l.IsProcessed(ctx, keyFn(item))
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerProcess(t *testing.T) {
+	ctx := context.Background()
+	errHandler := errors.New("handler failed")
+	ledger := grab.NewLedger[string](nil)
+
+	var calls []string
+	handle := func(key string, err error) error {
+		return ledger.Process(ctx, key, func(ctx context.Context) error {
+			calls = append(calls, key)
+			return err
+		})
+	}
+
+	assert.NoError(t, handle("a", nil))
+	assert.NoError(t, handle("a", nil), "already processed keys should be skipped")
+	assert.ErrorIs(t, handle("b", errHandler), errHandler)
+	assert.NoError(t, handle("b", nil), "failed keys should be processed again")
+	assert.Equal(t, []string{"a", "b", "b"}, calls)
+
+	done, err := ledger.IsProcessed(ctx, "b")
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
+
+func TestLedgerStoreError(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	ledger := grab.NewLedger[string](failingStore[string, time.Time]{err: errStore})
+
+	called := false
+	err := ledger.Process(context.Background(), "a", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, errStore)
+	assert.False(t, called)
+}
+
+func TestUnprocessed(t *testing.T) {
+	ctx := context.Background()
+	ledger := grab.NewLedger[string](nil)
+	assert.NoError(t, ledger.MarkProcessed(ctx, "b", "d"))
+
+	got, err := grab.Unprocessed(ctx, ledger, []string{"a", "b", "c", "d"}, func(s string) string { return s })
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, got)
+}
+
+func TestSinkLedger(t *testing.T) {
+	ctx := context.Background()
+	ledger := grab.NewLedger[string](nil)
+	assert.NoError(t, ledger.MarkProcessed(ctx, "a"))
+
+	var written []string
+	sink := grab.Sink[string]{
+		Retry:  grab.RetryPolicy{MaxAttempts: 1},
+		Ledger: ledger,
+		Key:    func(s string) string { return s },
+		WriteBatch: func(ctx context.Context, batch []string) ([]grab.ItemError[string], error) {
+			written = append(written, batch...)
+			return []grab.ItemError[string]{{Item: "c", Err: errors.New("failed")}}, nil
+		},
+	}
+
+	err := sink.WriteAll(ctx, []string{"a", "b", "c"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"b", "c"}, written, "items already in the ledger should be skipped")
+
+	for key, want := range map[string]bool{"a": true, "b": true, "c": false} {
+		done, err := ledger.IsProcessed(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, want, done, key)
+	}
+}
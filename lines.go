@@ -0,0 +1,101 @@
+package grab
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+	"strings"
+)
+
+// ReadLines returns a sequence of the lines read from 'r', without their trailing "\n" or "\r\n".
+// Unlike bufio.Scanner, there is no limit on the length of a line.
+//
+// Parameters:
+//   - r: The reader to read lines from. It is read lazily, as the sequence is iterated.
+//
+// Returns:
+//   - iter.Seq2[string, error]: A sequence of lines. If reading fails, the error is yielded
+//     with an empty line and iteration stops.
+//
+// Example:
+//
+//	for line, err := range ReadLines(file) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    importRecord(line)
+//	}
+//
+// Note: The sequence can only be iterated once, as it consumes the reader.
+func ReadLines(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield("", err)
+				return
+			}
+			if line == "" && err != nil {
+				return
+			}
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			if !yield(line, nil) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ReadChunks returns a sequence of batches of up to 'size' lines read from 'r', as in ReadLines.
+//
+// Parameters:
+//   - r: The reader to read lines from. It is read lazily, as the sequence is iterated.
+//   - size: The maximum number of lines in each batch. Must be greater than zero.
+//
+// Returns:
+//   - iter.Seq2[[]string, error]: A sequence of batches. Every batch except the last contains exactly 'size' lines.
+//     If reading fails, the error is yielded with a nil batch and iteration stops; lines read before the error
+//     are yielded in a batch first.
+//
+// Example:
+//
+//	for batch, err := range ReadChunks(file, 500) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if err := db.InsertMany(ctx, batch); err != nil {
+//	        return err
+//	    }
+//	}
+//
+// Note: This function panics if 'size' is less than 1.
+func ReadChunks(r io.Reader, size int) iter.Seq2[[]string, error] {
+	if size < 1 {
+		panic("grab: ReadChunks size must be greater than zero")
+	}
+	return func(yield func([]string, error) bool) {
+		batch := make([]string, 0, size)
+		for line, err := range ReadLines(r) {
+			if err != nil {
+				if len(batch) > 0 && !yield(batch, nil) {
+					return
+				}
+				yield(nil, err)
+				return
+			}
+			batch = append(batch, line)
+			if len(batch) == size {
+				if !yield(batch, nil) {
+					return
+				}
+				batch = make([]string, 0, size)
+			}
+		}
+		if len(batch) > 0 {
+			yield(batch, nil)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package grab_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "trailing newline", input: "a\nb\n", want: []string{"a", "b"}},
+		{name: "no trailing newline", input: "a\nb", want: []string{"a", "b"}},
+		{name: "crlf", input: "a\r\nb\r\n", want: []string{"a", "b"}},
+		{name: "blank lines", input: "a\n\nb\n", want: []string{"a", "", "b"}},
+		{name: "long line", input: strings.Repeat("x", 200_000) + "\n", want: []string{strings.Repeat("x", 200_000)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for line, err := range grab.ReadLines(strings.NewReader(tt.input)) {
+				assert.NoError(t, err)
+				got = append(got, line)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		r := io.MultiReader(strings.NewReader("a\n"), iotest.ErrReader(errors.New("mock")))
+		var got []string
+		var gotErr error
+		for line, err := range grab.ReadLines(r) {
+			if err != nil {
+				gotErr = err
+				break
+			}
+			got = append(got, line)
+		}
+		assert.Equal(t, []string{"a"}, got)
+		assert.EqualError(t, gotErr, "mock")
+	})
+}
+
+func TestReadChunks(t *testing.T) {
+	var got [][]string
+	for batch, err := range grab.ReadChunks(strings.NewReader("1\n2\n3\n4\n5\n"), 2) {
+		assert.NoError(t, err)
+		got = append(got, batch)
+	}
+	assert.Equal(t, [][]string{{"1", "2"}, {"3", "4"}, {"5"}}, got)
+
+	t.Run("read error", func(t *testing.T) {
+		r := io.MultiReader(strings.NewReader("1\n2\n3\n"), iotest.ErrReader(errors.New("mock")))
+		var got [][]string
+		var gotErr error
+		for batch, err := range grab.ReadChunks(r, 2) {
+			if err != nil {
+				gotErr = err
+				continue
+			}
+			got = append(got, batch)
+		}
+		assert.Equal(t, [][]string{{"1", "2"}, {"3"}}, got)
+		assert.EqualError(t, gotErr, "mock")
+	})
+}
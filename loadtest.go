@@ -0,0 +1,111 @@
+package grab
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// LoadTestReport summarises the results of a LoadTest.
+type LoadTestReport struct {
+	// Requests is the total number of calls made.
+	Requests int
+	// Errors is the number of calls which returned an error.
+	Errors int
+	// Elapsed is how long the load test ran for.
+	Elapsed time.Duration
+	// Min, Mean and Max are the minimum, mean and maximum call latencies.
+	Min, Mean, Max time.Duration
+	// P50, P90 and P99 are latency percentiles across all calls.
+	P50, P90, P99 time.Duration
+}
+
+// LoadTest calls a function repeatedly from a fixed number of concurrent workers for a duration,
+// and returns a report of latency percentiles and error counts.
+// It is a generic function that works with any result type 'T'.
+//
+// Parameters:
+//   - ctx: A context.Context used to stop the load test early. It is passed to each call of 'fn'.
+//   - concurrency: The number of workers calling 'fn' concurrently. Values less than 1 are treated as 1.
+//   - duration: How long to keep calling 'fn' for. Calls in flight when the duration elapses are allowed to finish.
+//   - fn: The function under test.
+//   - opts: Optional settings. WithClock sets the Clock used to measure latencies and to end the load test.
+//
+// Returns:
+//   - LoadTestReport: A summary of the calls made.
+//
+// Example:
+//
+//	report := LoadTest(ctx, 10, 30*time.Second, func(ctx context.Context) ([]User, error) {
+//	    return AllPages(ctx, listUsersPage)
+//	})
+//
+// // report.P99 holds the 99th percentile latency of listing all users with 10 concurrent callers
+//
+// Note: This function is useful for quickly characterising the latency and error behaviour of upstream APIs.
+func LoadTest[T any](ctx context.Context, concurrency int, duration time.Duration, fn func(ctx context.Context) (T, error), opts ...Option[Config]) LoadTestReport {
+	clock := newConfig(opts).Clock
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		errs      int
+		wg        sync.WaitGroup
+	)
+
+	start := clock.Now()
+	deadline := start.Add(duration)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil && clock.Now().Before(deadline) {
+				_, took, err := Timed(func() (T, error) { return fn(ctx) }, WithClock(clock))
+
+				mu.Lock()
+				latencies = append(latencies, took)
+				if err != nil {
+					errs++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := LoadTestReport{
+		Requests: len(latencies),
+		Errors:   errs,
+		Elapsed:  clock.Now().Sub(start),
+	}
+	if len(latencies) == 0 {
+		return report
+	}
+
+	slices.Sort(latencies)
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	report.Min = latencies[0]
+	report.Max = latencies[len(latencies)-1]
+	report.Mean = total / time.Duration(len(latencies))
+	report.P50 = percentile(latencies, 50)
+	report.P90 = percentile(latencies, 90)
+	report.P99 = percentile(latencies, 99)
+	return report
+}
+
+// percentile returns the nearest-rank percentile of a sorted, non-empty slice of durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
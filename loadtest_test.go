@@ -0,0 +1,61 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTest(t *testing.T) {
+	var calls int64
+	report := grab.LoadTest(context.Background(), 4, 20*time.Millisecond, func(ctx context.Context) (int, error) {
+		n := atomic.AddInt64(&calls, 1)
+		time.Sleep(time.Millisecond)
+		if n%2 == 0 {
+			return 0, errors.New("mock")
+		}
+		return 1, nil
+	})
+
+	assert.Equal(t, int(atomic.LoadInt64(&calls)), report.Requests)
+	assert.Equal(t, report.Requests/2, report.Errors)
+	assert.GreaterOrEqual(t, report.Min, time.Millisecond)
+	assert.LessOrEqual(t, report.Min, report.P50)
+	assert.LessOrEqual(t, report.P50, report.P90)
+	assert.LessOrEqual(t, report.P90, report.P99)
+	assert.LessOrEqual(t, report.P99, report.Max)
+	assert.GreaterOrEqual(t, report.Elapsed, 20*time.Millisecond)
+}
+
+func TestLoadTestFakeClock(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	report := grab.LoadTest(context.Background(), 1, 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		clock.Advance(time.Millisecond)
+		return 1, nil
+	}, grab.WithClock(clock))
+
+	assert.Equal(t, grab.LoadTestReport{
+		Requests: 10,
+		Elapsed:  10 * time.Millisecond,
+		Min:      time.Millisecond,
+		Mean:     time.Millisecond,
+		Max:      time.Millisecond,
+		P50:      time.Millisecond,
+		P90:      time.Millisecond,
+		P99:      time.Millisecond,
+	}, report)
+}
+
+func TestLoadTestCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := grab.LoadTest(ctx, 2, time.Second, func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.Equal(t, grab.LoadTestReport{Elapsed: report.Elapsed}, report)
+}
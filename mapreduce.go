@@ -0,0 +1,49 @@
+package grab
+
+import "context"
+
+// MapReduce applies a mapper to each item concurrently with bounded parallelism, then folds the mapped
+// results into a single value with a reducer.
+// It is a generic function that works with any item type 'T', mapped type 'M' and result type 'R'.
+//
+// Parameters:
+//   - ctx: A context.Context passed to the mapper. It is cancelled for the remaining mappers if any mapper fails.
+//   - items: The items to process.
+//   - concurrency: The maximum number of mappers running at once. Values less than 1 are treated as 1.
+//   - mapper: A function which transforms an item. It is called concurrently.
+//   - reducer: A function which folds a mapped result into the accumulated value. It is called sequentially,
+//     in the order of 'items', so it doesn't need to be safe for concurrent use.
+//   - initial: The initial accumulated value.
+//
+// Returns:
+//   - R: The accumulated value.
+//   - error: The first error returned by a mapper, or the context error if the context was cancelled.
+//     If an error is returned, the accumulated value is 'initial'.
+//
+// Example:
+//
+//	total, err := MapReduce(ctx, accounts, 10, func(ctx context.Context, a Account) (float64, error) {
+//	    return fetchSpend(ctx, a)
+//	}, func(total float64, spend float64) float64 {
+//	    return total + spend
+//	}, 0)
+func MapReduce[T, M, R any](ctx context.Context, items []T, concurrency int, mapper func(ctx context.Context, item T) (M, error), reducer func(acc R, mapped M) R, initial R) (R, error) {
+	mapped := make([]M, len(items))
+	err := doIndexed(ctx, concurrency, items, func(ctx context.Context, i int, item T) error {
+		m, err := mapper(ctx, item)
+		if err != nil {
+			return err
+		}
+		mapped[i] = m
+		return nil
+	})
+	if err != nil {
+		return initial, err
+	}
+
+	acc := initial
+	for _, m := range mapped {
+		acc = reducer(acc, m)
+	}
+	return acc, nil
+}
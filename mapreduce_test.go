@@ -0,0 +1,86 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapReduce(t *testing.T) {
+	mockErr := errors.New("mock")
+
+	tests := []struct {
+		name        string
+		items       []int
+		concurrency int
+		failOn      int
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "empty",
+			concurrency: 2,
+			want:        "",
+		},
+		{
+			name:        "reduces in item order",
+			items:       []int{1, 2, 3, 4, 5},
+			concurrency: 3,
+			want:        "12345",
+		},
+		{
+			name:        "concurrency below one is treated as one",
+			items:       []int{1, 2},
+			concurrency: 0,
+			want:        "12",
+		},
+		{
+			name:        "mapper error",
+			items:       []int{1, 2, 3},
+			concurrency: 2,
+			failOn:      2,
+			wantErr:     mockErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grab.MapReduce(context.Background(), tt.items, tt.concurrency, func(ctx context.Context, i int) (string, error) {
+				if i == tt.failOn {
+					return "", mockErr
+				}
+				// finish in reverse order to check the reducer still sees items in order
+				time.Sleep(time.Duration(10-i) * time.Millisecond)
+				return strconv.Itoa(i), nil
+			}, func(acc string, s string) string {
+				return acc + s
+			}, "")
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMapReduceConcurrencyLimit(t *testing.T) {
+	var running, maxRunning int32
+	items := make([]int, 20)
+	_, err := grab.MapReduce(context.Background(), items, 4, func(ctx context.Context, i int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return 1, nil
+	}, func(acc, n int) int { return acc + n }, 0)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(4))
+}
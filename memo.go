@@ -0,0 +1,71 @@
+package grab
+
+import "sync"
+
+// CacheConfig holds the optional settings for CachedBy.
+type CacheConfig struct {
+	// MaxEntries is the maximum number of results kept. When the cache is full, the oldest result is evicted.
+	// Defaults to 0, which means the cache is unbounded.
+	MaxEntries int
+}
+
+// WithMaxEntries sets the maximum number of results CachedBy keeps.
+func WithMaxEntries(n int) Option[CacheConfig] {
+	return WithField(func(c *CacheConfig) *int { return &c.MaxEntries }, n)
+}
+
+// CachedBy wraps an expensive, pure per-item computation so that its result is computed once per key
+// and reused for later items with the same key. It is intended for functions passed to Map and similar
+// helpers which see the same inputs many times. The returned function is safe for concurrent use.
+//
+// Parameters:
+//   - keyFn: A function that returns the cache key for an item. Items with the same key must have the same result.
+//   - compute: The function to cache. It should be pure, because a cached result is returned in place of calling it.
+//   - opts: Optional settings. WithMaxEntries bounds the number of results kept.
+//
+// Returns:
+//   - func(T) R: A function which returns the cached result for an item's key, calling 'compute' on a miss.
+//
+// Example:
+//
+//	parse := CachedBy(func(s string) string { return s }, parseARN, WithMaxEntries(10_000))
+//	accounts := Map(resourceARNs, func(s string) string { return parse(s).AccountID })
+//
+// Note: 'compute' is called without holding a lock, so concurrent misses for the same key may each call it.
+// Eviction is first-in first-out, which keeps lookups cheap for hot keys.
+func CachedBy[T any, K comparable, R any](keyFn func(T) K, compute func(T) R, opts ...Option[CacheConfig]) func(T) R {
+	var cfg CacheConfig
+	ApplyOptions(&cfg, opts...)
+
+	var (
+		mu      sync.Mutex
+		results = make(map[K]R)
+		order   []K // insertion order, used for eviction when MaxEntries is set
+	)
+	return func(item T) R {
+		key := keyFn(item)
+		mu.Lock()
+		result, ok := results[key]
+		mu.Unlock()
+		if ok {
+			return result
+		}
+
+		result = compute(item)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := results[key]; ok {
+			return result
+		}
+		if cfg.MaxEntries > 0 {
+			if len(order) >= cfg.MaxEntries {
+				delete(results, order[0])
+				order = order[1:]
+			}
+			order = append(order, key)
+		}
+		results[key] = result
+		return result
+	}
+}
@@ -0,0 +1,73 @@
+package grab_test
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []grab.Option[grab.CacheConfig]
+		inputs    []string
+		wantCalls int32
+	}{
+		{
+			name:      "unbounded",
+			inputs:    []string{"a", "b", "a", "b", "a"},
+			wantCalls: 2,
+		},
+		{
+			name:      "oldest entry evicted",
+			opts:      []grab.Option[grab.CacheConfig]{grab.WithMaxEntries(2)},
+			inputs:    []string{"a", "b", "c", "a", "c"},
+			wantCalls: 4,
+		},
+		{
+			name:      "keyed by derived value",
+			inputs:    []string{"a", "A", "b"},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			upper := grab.CachedBy(strings.ToLower, func(s string) string {
+				calls.Add(1)
+				return strings.ToUpper(s)
+			}, tt.opts...)
+
+			got := grab.Map(tt.inputs, upper)
+			assert.Equal(t, grab.Map(tt.inputs, strings.ToUpper), got)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestCachedByConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	square := grab.CachedBy(func(n int) int { return n }, func(n int) int {
+		calls.Add(1)
+		return n * n
+	}, grab.WithMaxEntries(5))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				k := (n + i) % 10
+				assert.Equal(t, k*k, square(k))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Positive(t, calls.Load())
+}
@@ -0,0 +1,203 @@
+package grab
+
+import "cmp"
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// SumInts returns the sum of the values. The loop is unrolled across independent accumulators,
+// which lets the CPU add several values per cycle on large slices.
+//
+// Parameters:
+//   - values: A slice of integers to sum.
+//
+// Returns:
+//   - T: The sum of the values, or zero if the slice is empty. Overflow wraps, as with the + operator.
+//
+// Example:
+// total := SumInts([]int64{120, 80, 45}) // total will be 245
+func SumInts[T Integer](values []T) T {
+	var s0, s1, s2, s3 T
+	i := 0
+	for ; i+4 <= len(values); i += 4 {
+		s0 += values[i]
+		s1 += values[i+1]
+		s2 += values[i+2]
+		s3 += values[i+3]
+	}
+	for ; i < len(values); i++ {
+		s0 += values[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// MinMax returns the smallest and largest values in a single pass.
+//
+// Parameters:
+//   - values: A slice of ordered values.
+//
+// Returns:
+//   - T: The smallest value.
+//   - T: The largest value.
+//   - bool: False if the slice is empty, in which case both values are the zero value.
+//
+// Example:
+// lo, hi, ok := MinMax([]float64{3.5, 1.25, 9}) // lo will be 1.25, hi will be 9 and ok will be true
+//
+// Note: For floating-point values, NaNs are compared as in cmp.Less, so they are treated as less than any other value.
+func MinMax[T cmp.Ordered](values []T) (T, T, bool) {
+	if len(values) == 0 {
+		var zero T
+		return zero, zero, false
+	}
+	lo, hi := values[0], values[0]
+	// compare pairs with each other first, which needs three comparisons per two values rather than four
+	i := 1
+	for ; i+2 <= len(values); i += 2 {
+		a, b := values[i], values[i+1]
+		if cmp.Less(b, a) {
+			a, b = b, a
+		}
+		if cmp.Less(a, lo) {
+			lo = a
+		}
+		if cmp.Less(hi, b) {
+			hi = b
+		}
+	}
+	if i < len(values) {
+		if cmp.Less(values[i], lo) {
+			lo = values[i]
+		}
+		if cmp.Less(hi, values[i]) {
+			hi = values[i]
+		}
+	}
+	return lo, hi, true
+}
+
+// Dot returns the dot product of two slices, the sum of the products of their corresponding values.
+// The loop is unrolled across independent accumulators, as in SumInts.
+//
+// Parameters:
+//   - a: The first slice of numbers.
+//   - b: The second slice of numbers.
+//
+// Returns:
+//   - T: The dot product. If the slices have different lengths, the extra values in the longer slice are ignored.
+//
+// Example:
+// spend := Dot(hoursUsed, hourlyRates)
+//
+// Note: For floating-point values, the unrolled summation order means the result may differ from
+// a naive loop in the last few bits.
+func Dot[T Number](a, b []T) T {
+	n := min(len(a), len(b))
+	a, b = a[:n], b[:n]
+	var s0, s1, s2, s3 T
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < n; i++ {
+		s0 += a[i] * b[i]
+	}
+	return s0 + s1 + s2 + s3
+}
+
+// Sum returns the sum of the values, or zero if the slice is empty.
+//
+// Parameters:
+//   - values: A slice of numbers to sum.
+//
+// Returns:
+//   - T: The sum of the values.
+//
+// Example:
+// total := Sum([]float64{1.5, 2.25, 3}) // total will be 6.75
+//
+// Note: For large integer slices, SumInts is faster.
+func Sum[T Number](values []T) T {
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// SumBy returns the sum of the numbers returned by 'fn' for each item, or zero if the slice is empty.
+// It is a generic function that works with any item type 'T' and any numeric type 'N'.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - fn: A function that returns the number to sum for an item.
+//
+// Returns:
+//   - N: The sum of the numbers.
+//
+// Example:
+// totalSpend := SumBy(resources, func(r Resource) float64 { return r.MonthlyCost })
+func SumBy[T any, N Number](items []T, fn func(T) N) N {
+	var sum N
+	for _, item := range items {
+		sum += fn(item)
+	}
+	return sum
+}
+
+// Product returns the product of the values, or one if the slice is empty.
+//
+// Parameters:
+//   - values: A slice of numbers to multiply.
+//
+// Returns:
+//   - T: The product of the values.
+//
+// Example:
+// combinations := Product([]int{2, 3, 4}) // combinations will be 24
+func Product[T Number](values []T) T {
+	product := T(1)
+	for _, v := range values {
+		product *= v
+	}
+	return product
+}
+
+// Mean returns the arithmetic mean of the values.
+//
+// Parameters:
+//   - values: A slice of numbers.
+//
+// Returns:
+//   - float64: The mean of the values.
+//   - bool: False if the slice is empty, in which case the mean is zero.
+//
+// Example:
+// avg, ok := Mean([]int{1, 2, 3, 4}) // avg will be 2.5 and ok will be true
+//
+// Note: The values are summed as float64, so integer values do not overflow or truncate.
+func Mean[T Number](values []T) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	return sum / float64(len(values)), true
+}
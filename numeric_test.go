@@ -0,0 +1,108 @@
+package grab_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumInts(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		want   int64
+	}{
+		{name: "empty", values: nil, want: 0},
+		{name: "fewer than four", values: []int64{1, 2, 3}, want: 6},
+		{name: "multiple of four", values: []int64{1, 2, 3, 4, 5, 6, 7, 8}, want: 36},
+		{name: "remainder", values: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, -10}, want: 35},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.SumInts(tt.values))
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		wantLo float64
+		wantHi float64
+		wantOK bool
+	}{
+		{name: "empty", values: nil},
+		{name: "single", values: []float64{2}, wantLo: 2, wantHi: 2, wantOK: true},
+		{name: "even length", values: []float64{3.5, 1.25, 9, 4}, wantLo: 1.25, wantHi: 9, wantOK: true},
+		{name: "odd length", values: []float64{3.5, 1.25, 9, 4, -1}, wantLo: -1, wantHi: 9, wantOK: true},
+		{name: "infinities", values: []float64{0, math.Inf(1), math.Inf(-1)}, wantLo: math.Inf(-1), wantHi: math.Inf(1), wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, ok := grab.MinMax(tt.values)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantLo, lo)
+			assert.Equal(t, tt.wantHi, hi)
+		})
+	}
+}
+
+func TestDot(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []float64
+		b    []float64
+		want float64
+	}{
+		{name: "empty", want: 0},
+		{name: "equal length", a: []float64{1, 2, 3, 4, 5}, b: []float64{2, 2, 2, 2, 2}, want: 30},
+		{name: "different lengths", a: []float64{1, 2, 3}, b: []float64{10, 10}, want: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Dot(tt.a, tt.b))
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, 0, grab.Sum[int](nil))
+	assert.Equal(t, 6.75, grab.Sum([]float64{1.5, 2.25, 3}))
+	assert.Equal(t, uint8(6), grab.Sum([]uint8{1, 2, 3}))
+}
+
+func TestSumBy(t *testing.T) {
+	type resource struct{ Cost float64 }
+	resources := []resource{{Cost: 1.5}, {Cost: 2.5}}
+	assert.Equal(t, 4.0, grab.SumBy(resources, func(r resource) float64 { return r.Cost }))
+	assert.Equal(t, 0.0, grab.SumBy([]resource{}, func(r resource) float64 { return r.Cost }))
+}
+
+func TestProduct(t *testing.T) {
+	assert.Equal(t, 1, grab.Product[int](nil))
+	assert.Equal(t, 24, grab.Product([]int{2, 3, 4}))
+	assert.Equal(t, 0.5, grab.Product([]float64{0.25, 2}))
+}
+
+func TestMean(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		want   float64
+		wantOK bool
+	}{
+		{name: "empty", values: nil, want: 0, wantOK: false},
+		{name: "fractional mean", values: []int{1, 2, 3, 4}, want: 2.5, wantOK: true},
+		{name: "large values", values: []int{math.MaxInt64, math.MaxInt64}, want: math.MaxInt64, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := grab.Mean(tt.values)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
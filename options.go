@@ -0,0 +1,123 @@
+package grab
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Config holds the optional dependencies shared by grab's stateful and time-dependent helpers.
+// It is populated by passing Option[Config] values to those helpers.
+type Config struct {
+	// Clock is used to tell the time and create timers. Defaults to RealClock().
+	Clock Clock
+	// Rand is the source of randomness used by helpers with randomised behaviour.
+	// If nil, each helper uses its own time-seeded source.
+	Rand *rand.Rand
+	// Jitter is the fraction by which helpers with periodic behaviour randomly vary their intervals,
+	// to avoid many processes acting in lockstep. For example, 0.1 varies intervals by up to ±10%.
+	Jitter float64
+}
+
+// Option configures a value of type 'T', such as the configuration struct of a function which takes optional settings.
+// grab's own helpers take Option[Config], and downstream packages can use Option with their own configuration types
+// so that every API shares the same functional options mechanism.
+//
+// Example:
+//
+//	type ClientConfig struct {
+//	    Region  string
+//	    Retries int
+//	}
+//
+//	func WithRegion(region string) Option[ClientConfig] {
+//	    return WithField(func(c *ClientConfig) *string { return &c.Region }, region)
+//	}
+//
+//	func NewClient(opts ...Option[ClientConfig]) *Client {
+//	    cfg := ClientConfig{Region: "us-east-1", Retries: 3}
+//	    ApplyOptions(&cfg, opts...)
+//	    ...
+//	}
+type Option[T any] func(*T)
+
+// ApplyOptions applies the options to the value in order, so later options override earlier ones.
+// Nil options are ignored.
+//
+// Parameters:
+//   - cfg: A pointer to the value to configure, usually populated with defaults.
+//   - opts: The options to apply.
+func ApplyOptions[T any](cfg *T, opts ...Option[T]) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+}
+
+// WithField returns an Option which sets a single field to a value.
+// It removes the boilerplate from Option constructors which only assign a field.
+//
+// Parameters:
+//   - field: A function returning a pointer to the field to set.
+//   - value: The value to set the field to.
+//
+// Returns:
+//   - Option[T]: An Option which sets the field.
+//
+// Example:
+//
+//	func WithRetries(n int) Option[ClientConfig] {
+//	    return WithField(func(c *ClientConfig) *int { return &c.Retries }, n)
+//	}
+func WithField[T any, V any](field func(cfg *T) *V, value V) Option[T] {
+	return func(cfg *T) {
+		*field(cfg) = value
+	}
+}
+
+// WithClock sets the Clock used by a helper, so that time-dependent behaviour can be tested with a FakeClock.
+func WithClock(clock Clock) Option[Config] {
+	return WithField(func(c *Config) *Clock { return &c.Clock }, clock)
+}
+
+// WithRand sets the source of randomness used by a helper, so that randomised behaviour
+// is reproducible in tests and simulations when a seeded source is provided.
+//
+// Example:
+// fn := Flaky(fetchUsers, FaultSpec{ErrorRate: 0.5}, WithRand(rand.New(rand.NewSource(1))))
+func WithRand(r *rand.Rand) Option[Config] {
+	return WithField(func(c *Config) **rand.Rand { return &c.Rand }, r)
+}
+
+// WithJitter sets the fraction by which periodic intervals are randomly varied.
+// For example, WithJitter(0.1) varies intervals by up to ±10%.
+func WithJitter(fraction float64) Option[Config] {
+	return WithField(func(c *Config) *float64 { return &c.Jitter }, fraction)
+}
+
+// jittered returns the duration randomly varied by the configured jitter fraction.
+func (c Config) jittered(d time.Duration, r *rand.Rand) time.Duration {
+	if c.Jitter <= 0 {
+		return d
+	}
+	delta := (r.Float64()*2 - 1) * c.Jitter * float64(d)
+	return d + time.Duration(delta)
+}
+
+// randOrDefault returns the configured source of randomness, or a new time-seeded source if none was configured.
+func (c Config) randOrDefault() *rand.Rand {
+	if c.Rand != nil {
+		return c.Rand
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// newConfig applies the options to a Config with default values.
+func newConfig(opts []Option[Config]) Config {
+	cfg := Config{Clock: RealClock()}
+	ApplyOptions(&cfg, opts...)
+	if cfg.Clock == nil {
+		cfg.Clock = RealClock()
+	}
+	return cfg
+}
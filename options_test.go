@@ -0,0 +1,41 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type clientConfig struct {
+	Region  string
+	Retries int
+}
+
+func withRegion(region string) grab.Option[clientConfig] {
+	return grab.WithField(func(c *clientConfig) *string { return &c.Region }, region)
+}
+
+func withRetries(n int) grab.Option[clientConfig] {
+	return grab.WithField(func(c *clientConfig) *int { return &c.Retries }, n)
+}
+
+func TestApplyOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []grab.Option[clientConfig]
+		want clientConfig
+	}{
+		{name: "defaults", want: clientConfig{Region: "us-east-1", Retries: 3}},
+		{name: "single option", opts: []grab.Option[clientConfig]{withRetries(5)}, want: clientConfig{Region: "us-east-1", Retries: 5}},
+		{name: "later options win", opts: []grab.Option[clientConfig]{withRegion("eu-west-1"), withRegion("ap-southeast-2")}, want: clientConfig{Region: "ap-southeast-2", Retries: 3}},
+		{name: "nil options are ignored", opts: []grab.Option[clientConfig]{nil, withRetries(0)}, want: clientConfig{Region: "us-east-1", Retries: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := clientConfig{Region: "us-east-1", Retries: 3}
+			grab.ApplyOptions(&cfg, tt.opts...)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrOutboxFull is returned by Outbox.Add when adding the events would exceed the outbox's maximum size.
+var ErrOutboxFull = errors.New("outbox is full")
+
+// Outbox buffers events during a logical transaction, and only flushes them to a sink when the transaction
+// is committed. If the transaction is rolled back, the events are discarded.
+// It is safe for concurrent use.
+//
+// Example:
+// outbox := NewOutbox(publishAuditEvents, 1000)
+//
+//	if err := outbox.Add(AuditEvent{Action: "grant"}); err != nil {
+//	    return err
+//	}
+//
+//	if err := applyChanges(ctx); err != nil {
+//	    outbox.Rollback()
+//	    return err
+//	}
+//
+// return outbox.Commit(ctx)
+type Outbox[T any] struct {
+	sink    func(ctx context.Context, events []T) error
+	maxSize int
+
+	mu     sync.Mutex
+	events []T
+}
+
+// NewOutbox creates an empty Outbox.
+//
+// Parameters:
+//   - sink: The function which receives the buffered events on Commit.
+//   - maxSize: The maximum number of events the outbox may hold. A value of zero or less means there is no limit.
+//
+// Returns:
+//   - *Outbox[T]: A new, empty Outbox.
+func NewOutbox[T any](sink func(ctx context.Context, events []T) error, maxSize int) *Outbox[T] {
+	return &Outbox[T]{sink: sink, maxSize: maxSize}
+}
+
+// Add buffers events. If adding them would exceed the maximum size, none are added and ErrOutboxFull is returned.
+func (o *Outbox[T]) Add(events ...T) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.maxSize > 0 && len(o.events)+len(events) > o.maxSize {
+		return ErrOutboxFull
+	}
+	o.events = append(o.events, events...)
+	return nil
+}
+
+// Len returns the number of buffered events.
+func (o *Outbox[T]) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.events)
+}
+
+// Commit flushes the buffered events to the sink. If there are no buffered events, the sink is not called.
+// If the sink returns an error, the events remain buffered so that Commit can be retried.
+// After a successful Commit the outbox is empty and can be reused for another transaction.
+func (o *Outbox[T]) Commit(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.events) == 0 {
+		return nil
+	}
+	if err := o.sink(ctx, o.events); err != nil {
+		return err
+	}
+	o.events = nil
+	return nil
+}
+
+// Rollback discards the buffered events.
+func (o *Outbox[T]) Rollback() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = nil
+}
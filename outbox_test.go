@@ -0,0 +1,42 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutbox(t *testing.T) {
+	var flushed [][]string
+	sinkErr := errors.New("mock")
+	fail := true
+	outbox := grab.NewOutbox(func(ctx context.Context, events []string) error {
+		if fail {
+			return sinkErr
+		}
+		flushed = append(flushed, events)
+		return nil
+	}, 3)
+
+	assert.NoError(t, outbox.Commit(context.Background()), "empty commit doesn't call the sink")
+
+	assert.NoError(t, outbox.Add("a", "b"))
+	assert.ErrorIs(t, outbox.Add("c", "d"), grab.ErrOutboxFull)
+	assert.Equal(t, 2, outbox.Len())
+
+	assert.ErrorIs(t, outbox.Commit(context.Background()), sinkErr)
+	assert.Equal(t, 2, outbox.Len(), "events are kept when the sink fails")
+
+	fail = false
+	assert.NoError(t, outbox.Commit(context.Background()))
+	assert.Equal(t, [][]string{{"a", "b"}}, flushed)
+	assert.Equal(t, 0, outbox.Len())
+
+	assert.NoError(t, outbox.Add("e"))
+	outbox.Rollback()
+	assert.NoError(t, outbox.Commit(context.Background()))
+	assert.Equal(t, [][]string{{"a", "b"}}, flushed, "rolled back events are discarded")
+}
@@ -0,0 +1,109 @@
+package grab
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// parallelSortThreshold is the number of items below which SortParallelBy sorts on a single goroutine,
+// as the cost of coordinating goroutines outweighs the benefit for small slices.
+const parallelSortThreshold = 1 << 14
+
+// SortParallelBy returns a copy of the items sorted in ascending order of the key returned by 'keyFn'.
+// Large slices are sorted using a parallel merge sort across all available CPUs.
+// The sort is stable, so items with equal keys keep their original order.
+//
+// Parameters:
+//   - items: A slice of items of type 'T' to sort. It is not modified.
+//   - keyFn: A function that returns the key to sort an item by. It is called exactly once per item.
+//
+// Returns:
+//   - []T: A new slice containing the sorted items.
+//
+// Example:
+// sorted := SortParallelBy(resources, func(r Resource) string { return r.ARN })
+//
+// Note: This function is useful for sorting millions of records, where a single-threaded sort
+// is a measurable part of the total processing time.
+func SortParallelBy[T any, K cmp.Ordered](items []T, keyFn func(T) K) []T {
+	type keyed struct {
+		key  K
+		item T
+	}
+	compare := func(a, b keyed) int { return cmp.Compare(a.key, b.key) }
+
+	src := make([]keyed, len(items))
+	for i, item := range items {
+		src[i] = keyed{key: keyFn(item), item: item}
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if len(src) < parallelSortThreshold || workers < 2 {
+		slices.SortStableFunc(src, compare)
+	} else {
+		// sort contiguous runs concurrently, then merge adjacent pairs of runs until one remains.
+		// Runs are always merged with their neighbour, which keeps the sort stable.
+		runSize := (len(src) + workers - 1) / workers
+		var runs [][2]int
+		for start := 0; start < len(src); start += runSize {
+			runs = append(runs, [2]int{start, min(start+runSize, len(src))})
+		}
+
+		var wg sync.WaitGroup
+		for _, run := range runs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				slices.SortStableFunc(src[run[0]:run[1]], compare)
+			}()
+		}
+		wg.Wait()
+
+		dst := make([]keyed, len(src))
+		for len(runs) > 1 {
+			var merged [][2]int
+			for i := 0; i < len(runs); i += 2 {
+				if i+1 == len(runs) {
+					copy(dst[runs[i][0]:runs[i][1]], src[runs[i][0]:runs[i][1]])
+					merged = append(merged, runs[i])
+					continue
+				}
+				left, right := runs[i], runs[i+1]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					mergeRuns(dst[left[0]:right[1]], src[left[0]:left[1]], src[right[0]:right[1]], compare)
+				}()
+				merged = append(merged, [2]int{left[0], right[1]})
+			}
+			wg.Wait()
+			src, dst = dst, src
+			runs = merged
+		}
+	}
+
+	result := make([]T, len(src))
+	for i, k := range src {
+		result[i] = k.item
+	}
+	return result
+}
+
+// mergeRuns merges two sorted runs into 'dst', taking from 'left' when keys are equal so that the merge is stable.
+func mergeRuns[T any](dst, left, right []T, compare func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if compare(right[j], left[i]) < 0 {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
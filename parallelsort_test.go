@@ -0,0 +1,45 @@
+package grab_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortParallelBy(t *testing.T) {
+	type record struct {
+		Key   int
+		Index int
+	}
+
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "empty", n: 0},
+		{name: "small", n: 100},
+		{name: "large", n: 100_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			items := make([]record, tt.n)
+			for i := range items {
+				// use a small key range so there are many duplicates to check stability
+				items[i] = record{Key: r.Intn(100), Index: i}
+			}
+			original := slices.Clone(items)
+
+			got := grab.SortParallelBy(items, func(r record) int { return r.Key })
+
+			want := slices.Clone(items)
+			slices.SortStableFunc(want, func(a, b record) int { return a.Key - b.Key })
+			assert.Equal(t, len(want), len(got))
+			assert.True(t, slices.Equal(want, got))
+			assert.Equal(t, original, items, "input should not be modified")
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package grab
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"slices"
+)
+
+// PersistentList is an immutable singly linked list. Prepending returns a new list which shares
+// its tail with the original, so many variants of a large list can be built cheaply.
+// The zero value is an empty list, and lists are safe to share between goroutines.
+//
+// Example:
+// base := PersistentList[string]{}.Prepend("c").Prepend("b")
+// a := base.Prepend("a1") // a1, b, c
+// b := base.Prepend("a2") // a2, b, c, sharing b and c with a
+type PersistentList[T any] struct {
+	head *listNode[T]
+	size int
+}
+
+type listNode[T any] struct {
+	value T
+	next  *listNode[T]
+}
+
+// NewPersistentList creates a list containing the items in order.
+func NewPersistentList[T any](items ...T) PersistentList[T] {
+	var l PersistentList[T]
+	for i := len(items) - 1; i >= 0; i-- {
+		l = l.Prepend(items[i])
+	}
+	return l
+}
+
+// Prepend returns a new list with the value added to the front. The original list is unchanged.
+func (l PersistentList[T]) Prepend(value T) PersistentList[T] {
+	return PersistentList[T]{head: &listNode[T]{value: value, next: l.head}, size: l.size + 1}
+}
+
+// Head returns the first value in the list, or false if the list is empty.
+func (l PersistentList[T]) Head() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head.value, true
+}
+
+// Tail returns the list without its first value. The tail of an empty list is empty.
+func (l PersistentList[T]) Tail() PersistentList[T] {
+	if l.head == nil {
+		return l
+	}
+	return PersistentList[T]{head: l.head.next, size: l.size - 1}
+}
+
+// Len returns the number of values in the list.
+func (l PersistentList[T]) Len() int {
+	return l.size
+}
+
+// Slice returns the values of the list in order.
+func (l PersistentList[T]) Slice() []T {
+	result := make([]T, 0, l.size)
+	for n := l.head; n != nil; n = n.next {
+		result = append(result, n.value)
+	}
+	return result
+}
+
+// persistentSeed is shared by every PersistentMap, so that maps derived from each other hash keys identically.
+var persistentSeed = maphash.MakeSeed()
+
+// PersistentMap is an immutable hash map. Setting or deleting a key returns a new map which shares
+// most of its structure with the original, so many variants of a large map can be built without full copies.
+// The zero value is an empty map, and maps are safe to share between goroutines.
+//
+// Example:
+// base := PersistentMap[string, bool]{}.Set("s3:GetObject", true)
+// withPut := base.Set("s3:PutObject", true) // base is unchanged
+//
+// Note: This type is useful for evaluating many what-if variants of a large configuration snapshot,
+// such as during policy evaluation.
+type PersistentMap[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	size int
+}
+
+// hamtNode is a node of a hash array mapped trie, consuming 5 bits of the key hash per level.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a subtree, or the leaves for a single hash (more than one on a full hash collision).
+type hamtEntry[K comparable, V any] struct {
+	node   *hamtNode[K, V]
+	hash   uint64
+	leaves []hamtLeaf[K, V]
+}
+
+type hamtLeaf[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Get returns the value for a key, and whether it was present.
+func (m PersistentMap[K, V]) Get(key K) (V, bool) {
+	hash := maphash.Comparable(persistentSeed, key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += 5 {
+		bit := uint32(1) << ((hash >> shift) & 31)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := n.entries[bits.OnesCount32(n.bitmap&(bit-1))]
+		if e.node != nil {
+			n = e.node
+			continue
+		}
+		if e.hash == hash {
+			for _, leaf := range e.leaves {
+				if leaf.key == key {
+					return leaf.value, true
+				}
+			}
+		}
+		break
+	}
+	var zero V
+	return zero, false
+}
+
+// Set returns a new map with the key set to the value. The original map is unchanged.
+func (m PersistentMap[K, V]) Set(key K, value V) PersistentMap[K, V] {
+	hash := maphash.Comparable(persistentSeed, key)
+	root, added := m.root.set(hash, 0, hamtLeaf[K, V]{key: key, value: value})
+	size := m.size
+	if added {
+		size++
+	}
+	return PersistentMap[K, V]{root: root, size: size}
+}
+
+// Delete returns a new map without the key. The original map is unchanged.
+func (m PersistentMap[K, V]) Delete(key K) PersistentMap[K, V] {
+	hash := maphash.Comparable(persistentSeed, key)
+	root, removed := m.root.delete(hash, 0, key)
+	if !removed {
+		return m
+	}
+	return PersistentMap[K, V]{root: root, size: m.size - 1}
+}
+
+// Len returns the number of keys in the map.
+func (m PersistentMap[K, V]) Len() int {
+	return m.size
+}
+
+// Range calls 'fn' for each key and value in an unspecified order, stopping early if 'fn' returns false.
+func (m PersistentMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.root.each(fn)
+}
+
+func (n *hamtNode[K, V]) set(hash uint64, shift uint, leaf hamtLeaf[K, V]) (*hamtNode[K, V], bool) {
+	if n == nil {
+		n = &hamtNode[K, V]{}
+	}
+	bit := uint32(1) << ((hash >> shift) & 31)
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+
+	if n.bitmap&bit == 0 {
+		return &hamtNode[K, V]{
+			bitmap:  n.bitmap | bit,
+			entries: slices.Insert(slices.Clone(n.entries), pos, hamtEntry[K, V]{hash: hash, leaves: []hamtLeaf[K, V]{leaf}}),
+		}, true
+	}
+
+	e := n.entries[pos]
+	added := false
+	switch {
+	case e.node != nil:
+		e.node, added = e.node.set(hash, shift+5, leaf)
+	case e.hash == hash:
+		i := slices.IndexFunc(e.leaves, func(l hamtLeaf[K, V]) bool { return l.key == leaf.key })
+		e.leaves = slices.Clone(e.leaves)
+		if i >= 0 {
+			e.leaves[i] = leaf
+		} else {
+			e.leaves = append(e.leaves, leaf)
+			added = true
+		}
+	default:
+		// two different hashes share this slot, so push the existing leaves down into a new subtree
+		sub := &hamtNode[K, V]{
+			bitmap:  uint32(1) << ((e.hash >> (shift + 5)) & 31),
+			entries: []hamtEntry[K, V]{e},
+		}
+		e = hamtEntry[K, V]{}
+		e.node, added = sub.set(hash, shift+5, leaf)
+	}
+
+	entries := slices.Clone(n.entries)
+	entries[pos] = e
+	return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}, added
+}
+
+func (n *hamtNode[K, V]) delete(hash uint64, shift uint, key K) (*hamtNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	bit := uint32(1) << ((hash >> shift) & 31)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := bits.OnesCount32(n.bitmap & (bit - 1))
+	e := n.entries[pos]
+
+	if e.node != nil {
+		child, removed := e.node.delete(hash, shift+5, key)
+		if !removed {
+			return n, false
+		}
+		if child == nil {
+			return n.without(pos, bit), true
+		}
+		entries := slices.Clone(n.entries)
+		entries[pos] = hamtEntry[K, V]{node: child}
+		return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}, true
+	}
+
+	if e.hash != hash {
+		return n, false
+	}
+	i := slices.IndexFunc(e.leaves, func(l hamtLeaf[K, V]) bool { return l.key == key })
+	if i < 0 {
+		return n, false
+	}
+	if len(e.leaves) == 1 {
+		return n.without(pos, bit), true
+	}
+	entries := slices.Clone(n.entries)
+	entries[pos] = hamtEntry[K, V]{hash: hash, leaves: slices.Delete(slices.Clone(e.leaves), i, i+1)}
+	return &hamtNode[K, V]{bitmap: n.bitmap, entries: entries}, true
+}
+
+// without returns a copy of the node without the entry at 'pos', or nil if the node would be empty.
+func (n *hamtNode[K, V]) without(pos int, bit uint32) *hamtNode[K, V] {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	return &hamtNode[K, V]{
+		bitmap:  n.bitmap &^ bit,
+		entries: slices.Delete(slices.Clone(n.entries), pos, pos+1),
+	}
+}
+
+func (n *hamtNode[K, V]) each(fn func(key K, value V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if e.node != nil {
+			if !e.node.each(fn) {
+				return false
+			}
+			continue
+		}
+		for _, leaf := range e.leaves {
+			if !fn(leaf.key, leaf.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package grab_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentList(t *testing.T) {
+	var empty grab.PersistentList[string]
+	_, ok := empty.Head()
+	assert.False(t, ok)
+	assert.Equal(t, 0, empty.Tail().Len())
+	assert.Equal(t, []string{}, empty.Slice())
+
+	base := grab.NewPersistentList("b", "c")
+	a1 := base.Prepend("a1")
+	a2 := base.Prepend("a2")
+
+	assert.Equal(t, []string{"b", "c"}, base.Slice())
+	assert.Equal(t, []string{"a1", "b", "c"}, a1.Slice())
+	assert.Equal(t, []string{"a2", "b", "c"}, a2.Slice())
+	assert.Equal(t, 3, a1.Len())
+
+	head, ok := a1.Head()
+	assert.True(t, ok)
+	assert.Equal(t, "a1", head)
+	assert.Equal(t, base.Slice(), a1.Tail().Slice())
+}
+
+func TestPersistentMap(t *testing.T) {
+	var empty grab.PersistentMap[string, int]
+	base := empty.Set("a", 1).Set("b", 2)
+	updated := base.Set("a", 10).Set("c", 3)
+	deleted := updated.Delete("b").Delete("missing")
+
+	get := func(m grab.PersistentMap[string, int], key string) any {
+		v, ok := m.Get(key)
+		if !ok {
+			return nil
+		}
+		return v
+	}
+
+	assert.Equal(t, 0, empty.Len())
+	assert.Nil(t, get(empty, "a"))
+
+	assert.Equal(t, 2, base.Len())
+	assert.Equal(t, 1, get(base, "a"))
+	assert.Nil(t, get(base, "c"))
+
+	assert.Equal(t, 3, updated.Len())
+	assert.Equal(t, 10, get(updated, "a"))
+	assert.Equal(t, 2, get(updated, "b"))
+
+	assert.Equal(t, 2, deleted.Len())
+	assert.Nil(t, get(deleted, "b"))
+
+	got := map[string]int{}
+	deleted.Range(func(k string, v int) bool {
+		got[k] = v
+		return true
+	})
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, got)
+}
+
+func TestPersistentMapMatchesBuiltinMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var m grab.PersistentMap[int, int]
+	want := map[int]int{}
+	var snapshots []grab.PersistentMap[int, int]
+	var wantSnapshots []map[int]int
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			m = m.Delete(key)
+			delete(want, key)
+		} else {
+			m = m.Set(key, i)
+			want[key] = i
+		}
+		if i%1000 == 0 {
+			snapshots = append(snapshots, m)
+			wantSnapshots = append(wantSnapshots, grab.FreezeMap(want))
+		}
+	}
+
+	check := func(m grab.PersistentMap[int, int], want map[int]int) {
+		assert.Equal(t, len(want), m.Len())
+		got := map[int]int{}
+		m.Range(func(k, v int) bool {
+			got[k] = v
+			return true
+		})
+		assert.Equal(t, want, got)
+		for k, v := range want {
+			gotV, ok := m.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, v, gotV)
+		}
+	}
+	check(m, want)
+	for i := range snapshots {
+		check(snapshots[i], wantSnapshots[i])
+	}
+}
@@ -0,0 +1,65 @@
+package grab_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	var created int
+	pool := grab.NewPool(func() *[]int {
+		created++
+		return new([]int)
+	}, func(s *[]int) { *s = (*s)[:0] })
+
+	s := pool.Get()
+	*s = append(*s, 1, 2, 3)
+	pool.Put(s)
+
+	got := pool.Get()
+	assert.Empty(t, *got)
+	assert.GreaterOrEqual(t, created, 1)
+}
+
+func TestWithBuffer(t *testing.T) {
+	t.Run("buffer is empty", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			err := grab.WithBuffer(func(buf *bytes.Buffer) error {
+				assert.Equal(t, 0, buf.Len())
+				buf.WriteString("hello")
+				return nil
+			})
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		err := grab.WithBuffer(func(buf *bytes.Buffer) error {
+			return errors.New("mock")
+		})
+		assert.EqualError(t, err, "mock")
+	})
+}
+
+func TestWithGzipWriter(t *testing.T) {
+	for _, input := range []string{"first export", "second export"} {
+		var compressed bytes.Buffer
+		err := grab.WithGzipWriter(&compressed, func(zw *gzip.Writer) error {
+			_, err := zw.Write([]byte(input))
+			return err
+		})
+		assert.NoError(t, err)
+
+		zr, err := gzip.NewReader(&compressed)
+		assert.NoError(t, err)
+		got, err := io.ReadAll(zr)
+		assert.NoError(t, err)
+		assert.Equal(t, input, string(got))
+	}
+}
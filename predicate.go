@@ -0,0 +1,82 @@
+package grab
+
+import "strings"
+
+// And returns a predicate which is true if all of the predicates are true. It stops at the first false predicate.
+// With no predicates, it is always true.
+//
+// Example:
+//
+//	activeAdmins := Filter(users, And(
+//	    func(u User) bool { return u.Active },
+//	    func(u User) bool { return u.Admin },
+//	))
+func And[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, pred := range preds {
+			if !pred(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate which is true if any of the predicates are true. It stops at the first true predicate.
+// With no predicates, it is always false.
+//
+// Example:
+// privileged := Filter(users, Or(isAdmin, isOwner))
+func Or[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, pred := range preds {
+			if pred(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate which negates 'pred'.
+//
+// Example:
+// external := Filter(users, Not(isEmployee))
+func Not[T any](pred func(T) bool) func(T) bool {
+	return func(v T) bool {
+		return !pred(v)
+	}
+}
+
+// EqualTo returns a predicate which is true for values equal to 'target'.
+//
+// Example:
+// i := FindIndex(regions, EqualTo("us-east-1"))
+func EqualTo[T comparable](target T) func(T) bool {
+	return func(v T) bool {
+		return v == target
+	}
+}
+
+// In returns a predicate which is true for any of the provided values.
+// The values are copied into a set, so the predicate is cheap to call with many values.
+//
+// Example:
+// allowed := Filter(regions, In("us-east-1", "us-west-2"))
+func In[T comparable](values ...T) func(T) bool {
+	set := MapFromSlice(values, struct{}{})
+	return func(v T) bool {
+		_, ok := set[v]
+		return ok
+	}
+}
+
+// HasPrefixBy returns a predicate which is true when the string returned by 'fn' starts with 'prefix'.
+//
+// Example:
+// prodAccounts := Filter(accounts, HasPrefixBy(func(a Account) string { return a.Name }, "prod-"))
+func HasPrefixBy[T any](fn func(T) string, prefix string) func(T) bool {
+	return func(v T) bool {
+		return strings.HasPrefix(fn(v), prefix)
+	}
+}
@@ -0,0 +1,42 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredicates(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	positive := func(n int) bool { return n > 0 }
+
+	tests := []struct {
+		name string
+		pred func(int) bool
+		want []int
+	}{
+		{name: "and", pred: grab.And(even, positive), want: []int{2, 4}},
+		{name: "and with no predicates", pred: grab.And[int](), want: []int{-2, -1, 0, 1, 2, 3, 4}},
+		{name: "or", pred: grab.Or(even, positive), want: []int{-2, 0, 1, 2, 3, 4}},
+		{name: "or with no predicates", pred: grab.Or[int](), want: nil},
+		{name: "not", pred: grab.Not(even), want: []int{-1, 1, 3}},
+		{name: "equal to", pred: grab.EqualTo(3), want: []int{3}},
+		{name: "in", pred: grab.In(0, 4, 5), want: []int{0, 4}},
+		{name: "composed", pred: grab.And(positive, grab.Not(grab.In(2, 3))), want: []int{1, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.Filter([]int{-2, -1, 0, 1, 2, 3, 4}, tt.pred)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHasPrefixBy(t *testing.T) {
+	type account struct{ Name string }
+	accounts := []account{{Name: "prod-billing"}, {Name: "dev-billing"}, {Name: "prod-web"}}
+
+	got := grab.Filter(accounts, grab.HasPrefixBy(func(a account) string { return a.Name }, "prod-"))
+	assert.Equal(t, []account{{Name: "prod-billing"}, {Name: "prod-web"}}, got)
+}
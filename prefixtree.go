@@ -0,0 +1,140 @@
+package grab
+
+import "sort"
+
+// PrefixTree is a trie of string keys, supporting exact lookups, longest-prefix matching
+// and walking all keys with a given prefix. The zero value is an empty PrefixTree.
+// It is not safe for concurrent use while it is being modified.
+//
+// Example:
+// var permissions PrefixTree[Permission]
+// permissions.Insert("arn:aws:s3:::reports/", readOnly)
+// permissions.Insert("arn:aws:s3:::reports/finance/", denied)
+//
+// key, p, ok := permissions.LongestPrefixMatch("arn:aws:s3:::reports/finance/q1.csv")
+// // key will be "arn:aws:s3:::reports/finance/" and p will be denied
+type PrefixTree[V any] struct {
+	root prefixNode[V]
+	size int
+}
+
+type prefixNode[V any] struct {
+	children map[byte]*prefixNode[V]
+	value    V
+	hasValue bool
+}
+
+// Insert sets the value for a key, replacing any existing value.
+func (t *PrefixTree[V]) Insert(key string, value V) {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		if n.children == nil {
+			n.children = make(map[byte]*prefixNode[V])
+		}
+		child, ok := n.children[key[i]]
+		if !ok {
+			child = &prefixNode[V]{}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	if !n.hasValue {
+		t.size++
+	}
+	n.value, n.hasValue = value, true
+}
+
+// Get returns the value for an exact key, and whether it was present.
+func (t *PrefixTree[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete removes a key, returning true if it was present.
+func (t *PrefixTree[V]) Delete(key string) bool {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		return false
+	}
+	var zero V
+	n.value, n.hasValue = zero, false
+	t.size--
+	return true
+}
+
+// Len returns the number of keys in the tree.
+func (t *PrefixTree[V]) Len() int {
+	return t.size
+}
+
+// LongestPrefixMatch returns the longest key in the tree which is a prefix of 's', along with its value.
+// It returns false if no key is a prefix of 's'.
+func (t *PrefixTree[V]) LongestPrefixMatch(s string) (string, V, bool) {
+	var (
+		match    string
+		value    V
+		hasMatch bool
+	)
+	n := &t.root
+	for i := 0; ; i++ {
+		if n.hasValue {
+			match, value, hasMatch = s[:i], n.value, true
+		}
+		if i == len(s) {
+			break
+		}
+		child, ok := n.children[s[i]]
+		if !ok {
+			break
+		}
+		n = child
+	}
+	return match, value, hasMatch
+}
+
+// WalkPrefix calls 'fn' for each key which starts with 'prefix', in lexicographic order,
+// stopping early if 'fn' returns false.
+func (t *PrefixTree[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+	buf := []byte(prefix)
+	n.walk(&buf, fn)
+}
+
+func (n *prefixNode[V]) walk(buf *[]byte, fn func(key string, value V) bool) bool {
+	if n.hasValue && !fn(string(*buf), n.value) {
+		return false
+	}
+	keys := make([]byte, 0, len(n.children))
+	for b := range n.children {
+		keys = append(keys, b)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, b := range keys {
+		*buf = append(*buf, b)
+		cont := n.children[b].walk(buf, fn)
+		*buf = (*buf)[:len(*buf)-1]
+		if !cont {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *PrefixTree[V]) find(key string) *prefixNode[V] {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		child, ok := n.children[key[i]]
+		if !ok {
+			return nil
+		}
+		n = child
+	}
+	return n
+}
@@ -0,0 +1,101 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixTree(t *testing.T) {
+	var tree grab.PrefixTree[int]
+	tree.Insert("arn:aws:s3:::reports/", 1)
+	tree.Insert("arn:aws:s3:::reports/finance/", 2)
+	tree.Insert("arn:aws:s3:::logs/", 3)
+	tree.Insert("arn:aws:s3:::logs/", 4)
+	assert.Equal(t, 3, tree.Len())
+
+	v, ok := tree.Get("arn:aws:s3:::logs/")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+	_, ok = tree.Get("arn:aws:s3:::")
+	assert.False(t, ok)
+
+	tests := []struct {
+		name      string
+		s         string
+		wantKey   string
+		wantValue int
+		wantOK    bool
+	}{
+		{
+			name:      "longest match",
+			s:         "arn:aws:s3:::reports/finance/q1.csv",
+			wantKey:   "arn:aws:s3:::reports/finance/",
+			wantValue: 2,
+			wantOK:    true,
+		},
+		{
+			name:      "shorter match",
+			s:         "arn:aws:s3:::reports/hr/q1.csv",
+			wantKey:   "arn:aws:s3:::reports/",
+			wantValue: 1,
+			wantOK:    true,
+		},
+		{
+			name:      "exact match",
+			s:         "arn:aws:s3:::logs/",
+			wantKey:   "arn:aws:s3:::logs/",
+			wantValue: 4,
+			wantOK:    true,
+		},
+		{
+			name: "no match",
+			s:    "arn:aws:ec2:::instance",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := tree.LongestPrefixMatch(tt.s)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestPrefixTreeWalkPrefix(t *testing.T) {
+	var tree grab.PrefixTree[string]
+	for _, key := range []string{"b", "ab", "a", "abc", "abd", ""} {
+		tree.Insert(key, key)
+	}
+
+	var got []string
+	tree.WalkPrefix("a", func(key, value string) bool {
+		got = append(got, key)
+		return true
+	})
+	assert.Equal(t, []string{"a", "ab", "abc", "abd"}, got)
+
+	got = nil
+	tree.WalkPrefix("", func(key, value string) bool {
+		got = append(got, key)
+		return len(got) < 3
+	})
+	assert.Equal(t, []string{"", "a", "ab"}, got)
+
+	tree.WalkPrefix("z", func(key, value string) bool {
+		t.Fatal("no keys start with z")
+		return true
+	})
+
+	assert.True(t, tree.Delete("ab"))
+	assert.False(t, tree.Delete("ab"))
+	got = nil
+	tree.WalkPrefix("ab", func(key, value string) bool {
+		got = append(got, key)
+		return true
+	})
+	assert.Equal(t, []string{"abc", "abd"}, got)
+	assert.Equal(t, 5, tree.Len())
+}
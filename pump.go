@@ -0,0 +1,104 @@
+package grab
+
+import (
+	"context"
+	"iter"
+)
+
+// PumpConfig holds the optional settings for Pump.
+type PumpConfig struct {
+	// BatchSize is the maximum number of items passed to the consumer at once. Defaults to 100.
+	BatchSize int
+	// MaxPending is the number of full batches which can wait for the consumer before the producer is paused.
+	// Defaults to 1.
+	MaxPending int
+}
+
+// WithBatchSize sets the maximum number of items Pump passes to the consumer at once.
+func WithBatchSize(n int) Option[PumpConfig] {
+	return WithField(func(c *PumpConfig) *int { return &c.BatchSize }, n)
+}
+
+// WithMaxPending sets the number of full batches which can wait for the consumer before Pump pauses the producer.
+func WithMaxPending(n int) Option[PumpConfig] {
+	return WithField(func(c *PumpConfig) *int { return &c.MaxPending }, n)
+}
+
+// Pump reads items from a producer and passes them to a consumer in batches, running the two concurrently.
+// When the consumer falls behind, the producer is paused rather than buffering items without bound,
+// so a lazy producer such as JSONPager.Seq fetches pages no faster than they can be written.
+//
+// Parameters:
+//   - ctx: A context.Context used for cancellation. It is passed to the consumer.
+//   - producer: A sequence of items. Iteration stops at the first error it yields.
+//   - consumer: The function called with each batch. It is never called concurrently with itself.
+//   - opts: Optional settings. WithBatchSize sets the maximum batch size, and WithMaxPending sets how many
+//     full batches can wait for the consumer.
+//
+// Returns:
+//   - error: The first error yielded by the producer or returned by the consumer, or the context error
+//     if the context was cancelled. Either error stops both sides.
+//
+// Example:
+//
+//	err := Pump(ctx, pager.Seq(ctx), func(ctx context.Context, batch []User) error {
+//	    return sink.WriteAll(ctx, batch)
+//	}, WithBatchSize(25))
+//
+// Note: At most BatchSize*(MaxPending+2) items are held in memory at once: one batch being filled by the producer,
+// up to MaxPending batches waiting, and one batch being consumed. A final partial batch is passed to the consumer
+// once the producer is exhausted.
+func Pump[T any](ctx context.Context, producer iter.Seq2[T, error], consumer func(ctx context.Context, batch []T) error, opts ...Option[PumpConfig]) error {
+	cfg := PumpConfig{BatchSize: 100, MaxPending: 1}
+	ApplyOptions(&cfg, opts...)
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	cfg.MaxPending = max(cfg.MaxPending, 0)
+
+	scope := NewScope(ctx)
+	batches := make(chan []T, cfg.MaxPending)
+
+	scope.Go(func(ctx context.Context) error {
+		defer close(batches)
+
+		batch := make([]T, 0, cfg.BatchSize)
+		send := func() error {
+			select {
+			case batches <- batch:
+				batch = make([]T, 0, cfg.BatchSize)
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		for item, err := range producer {
+			if err != nil {
+				return err
+			}
+			batch = append(batch, item)
+			if len(batch) == cfg.BatchSize {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+		}
+		if len(batch) > 0 {
+			return send()
+		}
+		return nil
+	})
+
+	scope.Go(func(ctx context.Context) error {
+		for batch := range batches {
+			if err := consumer(ctx, batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := scope.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
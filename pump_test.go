@@ -0,0 +1,117 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+// produce returns a sequence of the integers from 0 to n-1, yielding 'err' after them if it is not nil.
+func produce(n int, err error, produced *atomic.Int32) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for i := range n {
+			if produced != nil {
+				produced.Add(1)
+			}
+			if !yield(i, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(0, err)
+		}
+	}
+}
+
+func TestPump(t *testing.T) {
+	errProducer := errors.New("producer failed")
+	errConsumer := errors.New("consumer failed")
+
+	tests := []struct {
+		name        string
+		producer    iter.Seq2[int, error]
+		consumerErr error
+		opts        []grab.Option[grab.PumpConfig]
+		want        [][]int
+		wantErr     error
+	}{
+		{
+			name:     "batches with a final partial batch",
+			producer: produce(5, nil, nil),
+			opts:     []grab.Option[grab.PumpConfig]{grab.WithBatchSize(2)},
+			want:     [][]int{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			name:     "empty producer",
+			producer: produce(0, nil, nil),
+			want:     nil,
+		},
+		{
+			name:     "producer error",
+			producer: produce(3, errProducer, nil),
+			opts:     []grab.Option[grab.PumpConfig]{grab.WithBatchSize(2)},
+			want:     [][]int{{0, 1}},
+			wantErr:  errProducer,
+		},
+		{
+			name:        "consumer error",
+			producer:    produce(4, nil, nil),
+			consumerErr: errConsumer,
+			opts:        []grab.Option[grab.PumpConfig]{grab.WithBatchSize(2)},
+			want:        [][]int{{0, 1}},
+			wantErr:     errConsumer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]int
+			err := grab.Pump(context.Background(), tt.producer, func(ctx context.Context, batch []int) error {
+				got = append(got, slices.Clone(batch))
+				return tt.consumerErr
+			}, tt.opts...)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPumpBackpressure(t *testing.T) {
+	var produced atomic.Int32
+	release := make(chan struct{})
+
+	done := make(chan error)
+	go func() {
+		done <- grab.Pump(context.Background(), produce(100, nil, &produced), func(ctx context.Context, batch []int) error {
+			<-release
+			return nil
+		}, grab.WithBatchSize(2), grab.WithMaxPending(1))
+	}()
+
+	// one batch being consumed, one waiting, and one being filled
+	assert.Eventually(t, func() bool { return produced.Load() == 6 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(6), produced.Load(), "producer should be paused while the consumer is blocked")
+
+	close(release)
+	assert.NoError(t, receive(t, done))
+	assert.Equal(t, int32(100), produced.Load())
+}
+
+func TestPumpCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := grab.Pump(ctx, produce(10, nil, nil), func(ctx context.Context, batch []int) error {
+		cancel()
+		<-ctx.Done()
+		return nil
+	}, grab.WithBatchSize(1))
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,81 @@
+package grab
+
+// Freeze returns a defensive copy of a slice, so that the copy can be shared without
+// later changes to the original (or to the copy) affecting each other.
+//
+// Parameters:
+//   - items: The slice to copy.
+//
+// Returns:
+//   - []T: A copy of 'items'. A nil slice returns nil.
+//
+// Example:
+// users, _ := AllPages(ctx, listUsersPage)
+// cache.Store(Freeze(users))
+func Freeze[T any](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(items)), items...)
+}
+
+// FreezeMap returns a defensive copy of a map, so that the copy can be shared without
+// later changes to the original (or to the copy) affecting each other.
+//
+// Parameters:
+//   - m: The map to copy.
+//
+// Returns:
+//   - map[K]V: A copy of 'm'. A nil map returns nil.
+func FreezeMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	result := make(map[K]V, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// ReadOnly is a slice wrapper which only exposes read methods, so that it can be shared
+// across goroutines without accidental mutation. The zero value is an empty ReadOnly.
+//
+// Note that ReadOnly only protects the slice itself; if 'T' is a pointer or contains
+// references, the values they point to can still be modified.
+//
+// Example:
+// users := NewReadOnly(allUsers)
+// first := users.At(0)
+type ReadOnly[T any] struct {
+	items []T
+}
+
+// NewReadOnly creates a ReadOnly from a copy of the provided slice.
+func NewReadOnly[T any](items []T) ReadOnly[T] {
+	return ReadOnly[T]{items: Freeze(items)}
+}
+
+// Len returns the number of items.
+func (r ReadOnly[T]) Len() int {
+	return len(r.items)
+}
+
+// At returns the item at index 'i'. It panics if 'i' is out of range, like indexing a slice.
+func (r ReadOnly[T]) At(i int) T {
+	return r.items[i]
+}
+
+// Slice returns a copy of the items, which the caller is free to modify.
+func (r ReadOnly[T]) Slice() []T {
+	return Freeze(r.items)
+}
+
+// Each calls 'fn' for each item in order, stopping early if 'fn' returns false.
+func (r ReadOnly[T]) Each(fn func(i int, item T) bool) {
+	for i, item := range r.items {
+		if !fn(i, item) {
+			return
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []int
+	}{
+		{
+			name:  "nil",
+			items: nil,
+		},
+		{
+			name:  "empty",
+			items: []int{},
+		},
+		{
+			name:  "items",
+			items: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.Freeze(tt.items)
+			assert.Equal(t, tt.items, got)
+			if len(got) > 0 {
+				got[0] = 100
+				assert.NotEqual(t, tt.items[0], got[0])
+			}
+		})
+	}
+}
+
+func TestFreezeMap(t *testing.T) {
+	assert.Nil(t, grab.FreezeMap[string, int](nil))
+
+	m := map[string]int{"a": 1}
+	got := grab.FreezeMap(m)
+	assert.Equal(t, m, got)
+	got["b"] = 2
+	assert.NotContains(t, m, "b")
+}
+
+func TestReadOnly(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	ro := grab.NewReadOnly(items)
+	items[0] = "changed"
+
+	assert.Equal(t, 3, ro.Len())
+	assert.Equal(t, "a", ro.At(0))
+
+	s := ro.Slice()
+	s[1] = "changed"
+	assert.Equal(t, "b", ro.At(1))
+
+	var visited []string
+	ro.Each(func(i int, item string) bool {
+		visited = append(visited, item)
+		return i < 1
+	})
+	assert.Equal(t, []string{"a", "b"}, visited)
+
+	var empty grab.ReadOnly[int]
+	assert.Equal(t, 0, empty.Len())
+}
@@ -0,0 +1,141 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RefresherHealth describes the outcome of a Refresher's recent loads.
+type RefresherHealth struct {
+	// LastAttempt is when the most recent load finished.
+	LastAttempt time.Time
+	// LastSuccess is when the most recent successful load finished. It is the zero time if no load has succeeded.
+	LastSuccess time.Time
+	// LastError is the error returned by the most recent load, or nil if it succeeded.
+	LastError error
+	// ConsecutiveFailures is the number of loads which have failed since the last successful load.
+	ConsecutiveFailures int
+}
+
+// Healthy returns true if the most recent load succeeded.
+func (h RefresherHealth) Healthy() bool {
+	return !h.LastSuccess.IsZero() && h.LastError == nil
+}
+
+// Refresher keeps the latest successfully loaded value available, reloading it in the background at an interval.
+// If a load fails, the previously loaded value continues to be served and the failure is reported by Health.
+// It is safe for concurrent use.
+type Refresher[T any] struct {
+	load     func(ctx context.Context) (T, error)
+	interval time.Duration
+	cfg      Config
+	value    *Watched[T]
+
+	mu     sync.Mutex
+	health RefresherHealth
+}
+
+// NewRefresher starts loading a value in the background, immediately and then every interval,
+// until the context is cancelled.
+//
+// Parameters:
+//   - ctx: A context.Context which stops the background refresh when cancelled. It is passed to 'load'.
+//   - interval: The time between the end of one load and the start of the next. It must be greater than zero.
+//   - load: A function which loads the latest value, such as a call to AllPages.
+//   - opts: Optional settings. WithJitter varies the interval randomly, WithRand sets the source of randomness
+//     for the jitter, and WithClock sets the Clock used to wait between loads.
+//
+// Returns:
+//   - *Refresher[T]: A Refresher which serves the latest successfully loaded value.
+//
+// Example:
+//
+//	users := NewRefresher(ctx, time.Minute, func(ctx context.Context) ([]User, error) {
+//	    return AllPages(ctx, listUsersPage)
+//	}, WithJitter(0.1))
+//
+// current, ok := users.Get() // ok is false until the first load succeeds
+//
+// Note: This function panics if 'interval' is not greater than zero, as the value would be reloaded continuously.
+func NewRefresher[T any](ctx context.Context, interval time.Duration, load func(ctx context.Context) (T, error), opts ...Option[Config]) *Refresher[T] {
+	if interval <= 0 {
+		panic("grab: NewRefresher interval must be greater than zero")
+	}
+	var zero T
+	r := &Refresher[T]{
+		load:     load,
+		interval: interval,
+		cfg:      newConfig(opts),
+		value:    NewWatched(zero),
+	}
+	go r.run(ctx)
+	return r
+}
+
+func (r *Refresher[T]) run(ctx context.Context) {
+	rng := r.cfg.randOrDefault()
+	for {
+		r.refresh(ctx)
+		select {
+		case <-r.cfg.Clock.After(r.cfg.jittered(r.interval, rng)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Refresher[T]) refresh(ctx context.Context) {
+	value, err := r.load(ctx)
+	now := r.cfg.Clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.health.LastAttempt = now
+	r.health.LastError = err
+	if err != nil {
+		r.health.ConsecutiveFailures++
+		return
+	}
+	r.health.LastSuccess = now
+	r.health.ConsecutiveFailures = 0
+	r.value.Set(value)
+}
+
+// Get returns the latest successfully loaded value.
+// It returns the zero value of type 'T' and false if no load has succeeded yet.
+func (r *Refresher[T]) Get() (T, bool) {
+	value, version := r.value.Get()
+	return value, version > 0
+}
+
+// Health returns the outcome of the Refresher's recent loads.
+func (r *Refresher[T]) Health() RefresherHealth {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.health
+}
+
+// Watch returns a channel which receives the value each time it is successfully loaded.
+// The channel is closed when the context is cancelled.
+func (r *Refresher[T]) Watch(ctx context.Context) <-chan T {
+	return r.value.Watch(ctx)
+}
+
+// WaitReady blocks until the first load has succeeded or the context is cancelled.
+// If the context is cancelled first, the returned error includes the most recent load error.
+//
+// Example:
+// err := WarmUp(ctx, 30*time.Second, WarmUpLoader{Name: "users", Load: users.WaitReady})
+func (r *Refresher[T]) WaitReady(ctx context.Context) error {
+	select {
+	case <-r.value.Changed(0):
+		return nil
+	case <-ctx.Done():
+		if err := r.Health().LastError; err != nil {
+			return fmt.Errorf("%w: last load failed: %w", ctx.Err(), err)
+		}
+		return ctx.Err()
+	}
+}
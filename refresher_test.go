@@ -0,0 +1,89 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefresher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := grab.NewFakeClock(epoch)
+	var calls int32
+	results := []error{nil, errors.New("mock"), nil}
+	loaded := make(chan struct{})
+
+	r := grab.NewRefresher(ctx, time.Minute, func(ctx context.Context) (int, error) {
+		n := atomic.AddInt32(&calls, 1)
+		defer func() { loaded <- struct{}{} }()
+		return int(n), results[n-1]
+	}, grab.WithClock(clock))
+
+	<-loaded
+	assert.Eventually(t, func() bool { _, ok := r.Get(); return ok }, time.Second, time.Millisecond)
+	got, _ := r.Get()
+	assert.Equal(t, 1, got)
+	assert.True(t, r.Health().Healthy())
+
+	updates := r.Watch(ctx)
+
+	// a failed load keeps serving the stale value
+	advanceUntil(t, clock, loaded)
+	assert.Eventually(t, func() bool { return r.Health().ConsecutiveFailures == 1 }, time.Second, time.Millisecond)
+	got, _ = r.Get()
+	assert.Equal(t, 1, got)
+	assert.False(t, r.Health().Healthy())
+	assert.EqualError(t, r.Health().LastError, "mock")
+
+	advanceUntil(t, clock, loaded)
+	assert.Equal(t, 3, receive(t, updates))
+	assert.True(t, r.Health().Healthy())
+	assert.Equal(t, 0, r.Health().ConsecutiveFailures)
+}
+
+func TestRefresherNotLoaded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := grab.NewRefresher(ctx, time.Minute, func(ctx context.Context) (string, error) {
+		return "", errors.New("mock")
+	})
+	assert.Eventually(t, func() bool { return r.Health().ConsecutiveFailures > 0 }, time.Second, time.Millisecond)
+	got, ok := r.Get()
+	assert.False(t, ok)
+	assert.Equal(t, "", got)
+	assert.False(t, r.Health().Healthy())
+}
+
+func TestRefresherInvalidInterval(t *testing.T) {
+	load := func(ctx context.Context) (string, error) { return "", nil }
+	for _, interval := range []time.Duration{0, -time.Second} {
+		assert.PanicsWithValue(t, "grab: NewRefresher interval must be greater than zero", func() {
+			grab.NewRefresher(context.Background(), interval, load)
+		})
+	}
+}
+
+// advanceUntil advances the fake clock until a signal is received, since the goroutine under test
+// may not have started waiting on the clock yet when the test first advances it.
+func advanceUntil(t *testing.T, clock *grab.FakeClock, signal <-chan struct{}) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		clock.Advance(time.Minute)
+		select {
+		case <-signal:
+			return
+		case <-time.After(time.Millisecond):
+		case <-deadline:
+			t.Fatal("timed out waiting for signal")
+		}
+	}
+}
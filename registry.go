@@ -0,0 +1,89 @@
+package grab
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrNotRegistered is returned by Resolve when no value has been registered for the requested type and name.
+var ErrNotRegistered = errors.New("not registered")
+
+// Registry holds values keyed by their type, and optionally a name, so that providers can be wired together
+// by passing a single Registry rather than ever-growing constructor parameter lists.
+// It is safe for concurrent use, and the zero value is an empty Registry.
+//
+// Example:
+// var r Registry
+// Register[UserStore](&r, dynamoUserStore)
+// RegisterNamed[Notifier](&r, "slack", slackNotifier)
+//
+// store, err := Resolve[UserStore](&r)
+// slack, err := ResolveNamed[Notifier](&r, "slack")
+type Registry struct {
+	mu     sync.RWMutex
+	values map[registryKey]any
+}
+
+type registryKey struct {
+	typ  reflect.Type
+	name string
+}
+
+func keyFor[T any](name string) registryKey {
+	// TypeOf on a pointer and Elem supports interface types, which TypeOf on a value can't represent
+	return registryKey{typ: reflect.TypeOf((*T)(nil)).Elem(), name: name}
+}
+
+// Register stores a value in the Registry under type 'T', replacing any value previously registered for 'T'.
+// Specify 'T' explicitly to register a value under an interface type.
+func Register[T any](r *Registry, v T) {
+	RegisterNamed(r, "", v)
+}
+
+// RegisterNamed stores a value in the Registry under type 'T' and a name, so that several values of the
+// same type can be registered. It replaces any value previously registered for 'T' with the same name.
+func RegisterNamed[T any](r *Registry, name string, v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values == nil {
+		r.values = make(map[registryKey]any)
+	}
+	r.values[keyFor[T](name)] = v
+}
+
+// Resolve returns the value registered under type 'T'.
+// It returns an error wrapping ErrNotRegistered if no value has been registered.
+func Resolve[T any](r *Registry) (T, error) {
+	return ResolveNamed[T](r, "")
+}
+
+// ResolveNamed returns the value registered under type 'T' and a name.
+// It returns an error wrapping ErrNotRegistered if no value has been registered.
+func ResolveNamed[T any](r *Registry, name string) (T, error) {
+	key := keyFor[T](name)
+
+	r.mu.RLock()
+	v, ok := r.values[key]
+	r.mu.RUnlock()
+
+	if !ok {
+		var zero T
+		if name != "" {
+			return zero, fmt.Errorf("%s named %q: %w", key.typ, name, ErrNotRegistered)
+		}
+		return zero, fmt.Errorf("%s: %w", key.typ, ErrNotRegistered)
+	}
+	return v.(T), nil
+}
+
+// MustResolve returns the value registered under type 'T', and panics if no value has been registered.
+// It is intended for wiring code at startup, where a missing dependency is a programming error.
+func MustResolve[T any](r *Registry) T {
+	v, err := Resolve[T](r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,53 @@
+package grab_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{}
+
+func (englishGreeter) Greet() string { return "hello" }
+
+type frenchGreeter struct{}
+
+func (frenchGreeter) Greet() string { return "bonjour" }
+
+func TestRegistry(t *testing.T) {
+	var r grab.Registry
+
+	_, err := grab.Resolve[greeter](&r)
+	assert.ErrorIs(t, err, grab.ErrNotRegistered)
+	assert.EqualError(t, err, "grab_test.greeter: not registered")
+
+	grab.Register[greeter](&r, englishGreeter{})
+	grab.RegisterNamed[greeter](&r, "french", frenchGreeter{})
+	grab.Register(&r, 42)
+
+	g, err := grab.Resolve[greeter](&r)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", g.Greet())
+
+	g, err = grab.ResolveNamed[greeter](&r, "french")
+	assert.NoError(t, err)
+	assert.Equal(t, "bonjour", g.Greet())
+
+	_, err = grab.ResolveNamed[greeter](&r, "german")
+	assert.EqualError(t, err, `grab_test.greeter named "german": not registered`)
+
+	// a concrete type is registered separately to the interface it implements
+	_, err = grab.Resolve[englishGreeter](&r)
+	assert.ErrorIs(t, err, grab.ErrNotRegistered)
+
+	assert.Equal(t, 42, grab.MustResolve[int](&r))
+	assert.PanicsWithError(t, fmt.Sprintf("string: %s", grab.ErrNotRegistered), func() {
+		grab.MustResolve[string](&r)
+	})
+}
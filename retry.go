@@ -0,0 +1,142 @@
+package grab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfterer is implemented by errors which carry a hint from the server about how long to wait
+// before retrying, such as an HTTP 429 response with a Retry-After header.
+// Retry waits for the hinted duration instead of its exponential backoff delay.
+type RetryAfterer interface {
+	RetryAfter() time.Duration
+}
+
+// RetryAfter returns the delay requested by the response's Retry-After header,
+// which may be a number of seconds or an HTTP date. It returns zero if the header is missing or invalid.
+func (e *HTTPStatusError) RetryAfter() time.Duration {
+	v := e.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// RetryPolicy configures Retry.
+// The zero value makes 3 attempts, with delays starting at 100ms and doubling up to 30s.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first. Defaults to 3.
+	MaxAttempts int
+	// InitialDelay is the delay before the second attempt. Defaults to 100ms.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between attempts, including delays hinted by the server. Defaults to 30s.
+	MaxDelay time.Duration
+	// Multiplier is the factor the delay grows by after each attempt. Defaults to 2.
+	Multiplier float64
+	// ShouldRetry reports whether an error should be retried. Defaults to retrying every error
+	// except context cancellation and deadline errors, and errors classified by IsNotFound.
+	// Set it to IsRetryable to only retry errors which are known to be transient.
+	ShouldRetry func(err error) bool
+	// RetryAfter detects throttling errors which don't implement RetryAfterer, such as an AWS ThrottlingException,
+	// returning the delay to wait and true if the error is a throttling error.
+	// It is consulted before RetryAfterer, and may be nil.
+	RetryAfter func(err error) (time.Duration, bool)
+}
+
+// Retry calls 'fn' until it succeeds, the error is not retryable, the attempts are exhausted or the context is cancelled.
+// Between attempts it waits with exponential backoff, or for the delay hinted by a throttling error.
+// It is a generic function that works with any result type 'T'.
+//
+// Parameters:
+//   - ctx: A context.Context used for cancellation. It is passed to 'fn'.
+//   - policy: The RetryPolicy controlling the number of attempts and the delays between them.
+//   - fn: The function to call.
+//   - opts: Optional settings. WithJitter randomly varies the backoff delays, WithRand sets the source
+//     of randomness for the jitter, and WithClock sets the Clock used to wait between attempts.
+//
+// Returns:
+//   - T: The value returned by the successful call to 'fn'.
+//   - error: The error from the last attempt, or the context error if the context was cancelled while waiting.
+//
+// Example:
+//
+//	users, err := Retry(ctx, RetryPolicy{MaxAttempts: 5}, func(ctx context.Context) ([]User, error) {
+//	    return AllPages(ctx, listUsersPage)
+//	}, WithJitter(0.2))
+//
+// Note: Delays hinted by the server (through RetryPolicy.RetryAfter or an error implementing RetryAfterer)
+// are not jittered, so that requests are not retried earlier than the server asked.
+func Retry[T any](ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) (T, error), opts ...Option[Config]) (T, error) {
+	cfg := newConfig(opts)
+	rng := cfg.randOrDefault()
+	policy = policy.withDefaults()
+
+	delay := policy.InitialDelay
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.ShouldRetry(err) {
+			return result, err
+		}
+
+		wait, hinted := policy.hint(err)
+		if !hinted {
+			wait = cfg.jittered(delay, rng)
+			// clamp before converting, as repeated multiplication would overflow time.Duration after enough attempts
+			delay = time.Duration(min(float64(delay)*policy.Multiplier, float64(policy.MaxDelay)))
+		}
+		wait = min(wait, policy.MaxDelay)
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-cfg.Clock.After(wait):
+		}
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 3
+	}
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.Multiplier <= 0 {
+		p.Multiplier = 2
+	}
+	if p.ShouldRetry == nil {
+		p.ShouldRetry = func(err error) bool {
+			return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !IsNotFound(err)
+		}
+	}
+	return p
+}
+
+// hint returns the delay requested by a throttling error, and true if the error carried a hint.
+func (p RetryPolicy) hint(err error) (time.Duration, bool) {
+	if p.RetryAfter != nil {
+		if d, ok := p.RetryAfter(err); ok {
+			return d, true
+		}
+	}
+	var ra RetryAfterer
+	if errors.As(err, &ra) {
+		if d := ra.RetryAfter(); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,157 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+// instantClock records the durations waited for, and fires timers immediately.
+type instantClock struct {
+	grab.Clock
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- epoch
+	return ch
+}
+
+type throttleError struct{ after time.Duration }
+
+func (e throttleError) Error() string             { return "throttled" }
+func (e throttleError) RetryAfter() time.Duration { return e.after }
+
+var errThrottlingException = errors.New("ThrottlingException: rate exceeded")
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    grab.RetryPolicy
+		errs      []error
+		wantErr   error
+		wantCalls int
+		wantWaits []time.Duration
+	}{
+		{
+			name:      "succeeds first time",
+			wantCalls: 1,
+		},
+		{
+			name:      "exponential backoff",
+			errs:      []error{errors.New("mock"), errors.New("mock")},
+			wantCalls: 3,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:      "attempts exhausted",
+			policy:    grab.RetryPolicy{MaxAttempts: 2},
+			errs:      []error{errors.New("first"), errors.New("second"), errors.New("third")},
+			wantErr:   errors.New("second"),
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:      "not retryable",
+			policy:    grab.RetryPolicy{ShouldRetry: func(err error) bool { return false }},
+			errs:      []error{errors.New("mock")},
+			wantErr:   errors.New("mock"),
+			wantCalls: 1,
+		},
+		{
+			name:      "retry after hint",
+			errs:      []error{throttleError{after: 5 * time.Second}, errors.New("mock")},
+			wantCalls: 3,
+			wantWaits: []time.Duration{5 * time.Second, 100 * time.Millisecond},
+		},
+		{
+			name:      "hint is capped",
+			policy:    grab.RetryPolicy{MaxDelay: time.Second},
+			errs:      []error{throttleError{after: time.Minute}},
+			wantCalls: 2,
+			wantWaits: []time.Duration{time.Second},
+		},
+		{
+			name: "throttle detection hook",
+			policy: grab.RetryPolicy{RetryAfter: func(err error) (time.Duration, bool) {
+				return 2 * time.Second, errors.Is(err, errThrottlingException)
+			}},
+			errs:      []error{errThrottlingException},
+			wantCalls: 2,
+			wantWaits: []time.Duration{2 * time.Second},
+		},
+		{
+			name: "http retry-after header",
+			errs: []error{&grab.HTTPStatusError{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"7"}},
+			}},
+			wantCalls: 2,
+			wantWaits: []time.Duration{7 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &instantClock{Clock: grab.NewFakeClock(epoch)}
+			var calls int
+			got, err := grab.Retry(context.Background(), tt.policy, func(ctx context.Context) (int, error) {
+				calls++
+				if calls <= len(tt.errs) {
+					return 0, tt.errs[calls-1]
+				}
+				return 42, nil
+			}, grab.WithClock(clock))
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 42, got)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantWaits, clock.waits)
+		})
+	}
+}
+
+func TestRetryManyAttempts(t *testing.T) {
+	clock := &instantClock{Clock: grab.NewFakeClock(epoch)}
+	policy := grab.RetryPolicy{MaxAttempts: 100}
+	_, err := grab.Retry(context.Background(), policy, func(ctx context.Context) (int, error) {
+		return 0, errors.New("mock")
+	}, grab.WithClock(clock))
+	assert.EqualError(t, err, "mock")
+
+	// the backoff delay must not overflow, however many attempts are made
+	assert.Len(t, clock.waits, 99)
+	for _, wait := range clock.waits {
+		assert.Positive(t, wait)
+		assert.LessOrEqual(t, wait, 30*time.Second)
+	}
+	assert.Equal(t, 30*time.Second, clock.waits[98])
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := grab.NewFakeClock(epoch)
+	var calls int
+	_, err := grab.Retry(ctx, grab.RetryPolicy{}, func(ctx context.Context) (int, error) {
+		calls++
+		cancel()
+		return 0, errors.New("mock")
+	}, grab.WithClock(clock))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,88 @@
+package grab
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// Scope runs a group of goroutines whose lifetime is bounded by a call to Wait: every goroutine started with Go
+// has finished before Wait returns. If a goroutine fails or panics, the scope's context is cancelled so that
+// the remaining goroutines can stop early, and a panic is re-raised in the goroutine calling Wait.
+//
+// Example:
+// scope := NewScope(ctx)
+//
+//	for _, account := range accounts {
+//	    scope.Go(func(ctx context.Context) error {
+//	        return syncAccount(ctx, account)
+//	    })
+//	}
+//
+// err := scope.Wait()
+//
+// Note: Scope is the foundation for grab's concurrent helpers, such as Do and MapReduce. Unlike the package-level Go
+// function, panics are not swallowed: they cancel the scope and are propagated to the caller of Wait.
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	err      error
+	panicErr *PanicError
+}
+
+// NewScope creates a Scope whose context is derived from 'ctx'.
+func NewScope(ctx context.Context) *Scope {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scope{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the scope's context, which is cancelled when a goroutine fails or panics, or when Wait returns.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Go starts a goroutine in the scope, passing it the scope's context.
+func (s *Scope) Go(fn func(ctx context.Context) error) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				s.fail(nil, &PanicError{Value: r, Stack: debug.Stack()})
+			}
+		}()
+		if err := fn(s.ctx); err != nil {
+			s.fail(err, nil)
+		}
+	}()
+}
+
+func (s *Scope) fail(err error, panicErr *PanicError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if panicErr != nil && s.panicErr == nil {
+		s.panicErr = panicErr
+	}
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+	s.cancel()
+}
+
+// Wait blocks until every goroutine in the scope has finished, then cancels the scope's context.
+// It returns the first error returned by a goroutine. If a goroutine panicked, Wait panics with a *PanicError
+// containing the original panic value and stack trace.
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+	s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.panicErr != nil {
+		panic(s.panicErr)
+	}
+	return s.err
+}
@@ -0,0 +1,62 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("waits for all goroutines", func(t *testing.T) {
+		scope := grab.NewScope(context.Background())
+		var done int32
+		for i := 0; i < 10; i++ {
+			scope.Go(func(ctx context.Context) error {
+				atomic.AddInt32(&done, 1)
+				return nil
+			})
+		}
+		assert.NoError(t, scope.Wait())
+		assert.Equal(t, int32(10), atomic.LoadInt32(&done))
+		assert.Error(t, scope.Context().Err(), "context should be cancelled after Wait")
+	})
+
+	t.Run("error cancels the scope", func(t *testing.T) {
+		scope := grab.NewScope(context.Background())
+		scope.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		scope.Go(func(ctx context.Context) error {
+			return errors.New("mock")
+		})
+		assert.EqualError(t, scope.Wait(), "mock")
+	})
+
+	t.Run("panic is propagated to Wait", func(t *testing.T) {
+		scope := grab.NewScope(context.Background())
+		var cancelled int32
+		scope.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			atomic.StoreInt32(&cancelled, 1)
+			return nil
+		})
+		scope.Go(func(ctx context.Context) error {
+			panic("boom")
+		})
+
+		defer func() {
+			r := recover()
+			panicErr, ok := r.(*grab.PanicError)
+			if assert.True(t, ok, "expected a *PanicError, got %v", r) {
+				assert.Equal(t, "boom", panicErr.Value)
+			}
+			assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled), "other goroutines should finish before the panic is propagated")
+		}()
+		_ = scope.Wait()
+	})
+}
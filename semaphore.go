@@ -0,0 +1,123 @@
+package grab
+
+import "context"
+
+// Semaphore limits the number of operations running at once. It is safe for concurrent use.
+//
+// Example:
+// sem := NewSemaphore(10)
+//
+//	if err := sem.Acquire(ctx); err != nil {
+//	    return err
+//	}
+//	defer sem.Release()
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a Semaphore which allows up to 'n' operations at once. Values less than 1 are treated as 1.
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{slots: make(chan struct{}, max(n, 1))}
+}
+
+// Acquire blocks until a slot is available, or returns the context error if the context is cancelled first.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	// check the context first, so that a cancelled context never acquires a slot even when one is free
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires a slot without blocking, returning false if none is available.
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release releases a slot acquired with Acquire or TryAcquire. It panics if no slot is held.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("grab: Semaphore released without being acquired")
+	}
+}
+
+// WithSemaphore acquires a slot from the Semaphore, calls 'fn', and releases the slot when 'fn' returns.
+//
+// Parameters:
+//   - ctx: A context.Context used while waiting for a slot, and passed to 'fn'.
+//   - s: The Semaphore limiting concurrency.
+//   - fn: The function to call while holding the slot.
+//
+// Returns:
+//   - error: The context error if the context was cancelled while waiting for a slot, or the error returned by 'fn'.
+//
+// Example:
+//
+//	err := WithSemaphore(ctx, apiLimit, func(ctx context.Context) error {
+//	    return client.DeleteUser(ctx, id)
+//	})
+func WithSemaphore(ctx context.Context, s *Semaphore, fn func(ctx context.Context) error) error {
+	if err := s.Acquire(ctx); err != nil {
+		return err
+	}
+	defer s.Release()
+	return fn(ctx)
+}
+
+// Do calls 'fn' for each item concurrently, with at most 'n' calls running at once.
+// If any call fails, the context passed to the remaining calls is cancelled and no further calls are started.
+// If any call panics, Do panics with a *PanicError once the remaining calls have finished, as in Scope.
+// It is a generic function that works with any item type 'T'.
+//
+// Parameters:
+//   - ctx: A context.Context passed to 'fn'.
+//   - n: The maximum number of calls running at once. Values less than 1 are treated as 1.
+//   - items: The items to process.
+//   - fn: The function to call for each item. It is called concurrently.
+//
+// Returns:
+//   - error: The first error returned by 'fn', or the context error if the context was cancelled.
+//
+// Example:
+//
+//	err := Do(ctx, 10, userIDs, func(ctx context.Context, id string) error {
+//	    return client.DeleteUser(ctx, id)
+//	})
+func Do[T any](ctx context.Context, n int, items []T, fn func(ctx context.Context, item T) error) error {
+	return doIndexed(ctx, n, items, func(ctx context.Context, _ int, item T) error {
+		return fn(ctx, item)
+	})
+}
+
+// doIndexed is the implementation of Do, which also passes the index of each item to 'fn'.
+func doIndexed[T any](ctx context.Context, n int, items []T, fn func(ctx context.Context, i int, item T) error) error {
+	scope := NewScope(ctx)
+	sem := NewSemaphore(n)
+
+	for i, item := range items {
+		if sem.Acquire(scope.Context()) != nil {
+			break
+		}
+		scope.Go(func(ctx context.Context) error {
+			defer sem.Release()
+			return fn(ctx, i, item)
+		})
+	}
+
+	if err := scope.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,83 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := grab.NewSemaphore(2)
+	ctx := context.Background()
+
+	assert.NoError(t, sem.Acquire(ctx))
+	assert.True(t, sem.TryAcquire())
+	assert.False(t, sem.TryAcquire())
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sem.Acquire(timeout), context.DeadlineExceeded)
+
+	sem.Release()
+	assert.True(t, sem.TryAcquire())
+	sem.Release()
+	sem.Release()
+	assert.Panics(t, sem.Release)
+}
+
+func TestWithSemaphore(t *testing.T) {
+	sem := grab.NewSemaphore(1)
+	err := grab.WithSemaphore(context.Background(), sem, func(ctx context.Context) error {
+		assert.False(t, sem.TryAcquire(), "slot should be held while fn runs")
+		return errors.New("mock")
+	})
+	assert.EqualError(t, err, "mock")
+	assert.True(t, sem.TryAcquire(), "slot should be released after fn returns")
+}
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []int
+		failOn  int
+		wantErr bool
+	}{
+		{name: "empty", items: nil},
+		{name: "all succeed", items: []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{name: "error", items: []int{1, 2, 3, 4, 5, 6, 7, 8}, failOn: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning, sum int32
+			err := grab.Do(context.Background(), 3, tt.items, func(ctx context.Context, item int) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				if item == tt.failOn {
+					return errors.New("mock")
+				}
+				atomic.AddInt32(&sum, int32(item))
+				return nil
+			})
+
+			assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+			if tt.wantErr {
+				assert.EqualError(t, err, "mock")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int32(grab.SumInts(tt.items)), atomic.LoadInt32(&sum))
+		})
+	}
+}
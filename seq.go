@@ -0,0 +1,112 @@
+package grab
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrUnsortedInput is returned by helpers which require their input to be sorted by key
+// when a key is found out of order.
+var ErrUnsortedInput = errors.New("input is not sorted by key")
+
+// GroupBySeq consumes a sequence and groups its items by a key.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - seq: The sequence of items to group.
+//   - keyFn: A function returning the key to group an item by.
+//
+// Returns:
+//   - map[K][]T: The items grouped by key, with the items in each group in sequence order.
+//
+// Example:
+// byAccount := GroupBySeq(slices.Values(resources), func(r Resource) string { return r.AccountID })
+//
+// Note: This function holds every item in memory. For sequences too large to fit in memory,
+// use GroupBySeqSpill with input sorted by key.
+func GroupBySeq[T any, K comparable](seq iter.Seq[T], keyFn func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for item := range seq {
+		key := keyFn(item)
+		result[key] = append(result[key], item)
+	}
+	return result
+}
+
+// GroupBySeqSpill consumes a sequence sorted by key and emits each completed group to a callback,
+// buffering at most roughly 'maxBuffered' items of completed groups before emitting them.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - seq: The sequence of items to group. Items with the same key must be adjacent.
+//   - keyFn: A function returning the key to group an item by.
+//   - maxBuffered: The number of items in completed groups which triggers emitting them.
+//     A value of zero or less emits each group as soon as it is complete.
+//   - emit: A callback which receives each group, in sequence order. Emitting stops if it returns an error.
+//
+// Returns:
+//   - error: The error returned by 'emit', or an error wrapping ErrUnsortedInput if a key reappears
+//     after its group was completed while that group is still buffered.
+//
+// Example:
+//
+//	err := GroupBySeqSpill(exportRows, func(r Row) string { return r.UserID }, 10000, func(userID string, rows []Row) error {
+//	    return writeUserSummary(userID, rows)
+//	})
+//
+// Note: Memory use is bounded by the largest group plus 'maxBuffered' items, so multi-million item exports
+// can be grouped without holding the whole dataset in memory. Out of order keys can only be detected
+// while their earlier group is still buffered.
+func GroupBySeqSpill[T any, K comparable](seq iter.Seq[T], keyFn func(T) K, maxBuffered int, emit func(key K, group []T) error) error {
+	type group struct {
+		key   K
+		items []T
+	}
+
+	var (
+		completed     []group
+		completedKeys = make(map[K]struct{})
+		buffered      int
+		current       *group
+	)
+
+	flush := func() error {
+		for _, g := range completed {
+			if err := emit(g.key, g.items); err != nil {
+				return err
+			}
+		}
+		completed = nil
+		completedKeys = make(map[K]struct{})
+		buffered = 0
+		return nil
+	}
+
+	for item := range seq {
+		key := keyFn(item)
+		if current != nil && current.key == key {
+			current.items = append(current.items, item)
+			continue
+		}
+		if _, ok := completedKeys[key]; ok {
+			return fmt.Errorf("%w: key %v found after its group was completed", ErrUnsortedInput, key)
+		}
+		if current != nil {
+			completed = append(completed, *current)
+			completedKeys[current.key] = struct{}{}
+			buffered += len(current.items)
+			if buffered >= maxBuffered {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		current = &group{key: key, items: []T{item}}
+	}
+
+	if current != nil {
+		completed = append(completed, *current)
+	}
+	return flush()
+}
@@ -0,0 +1,105 @@
+package grab_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBySeq(t *testing.T) {
+	words := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	got := grab.GroupBySeq(slices.Values(words), func(s string) byte { return s[0] })
+	assert.Equal(t, map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}, got)
+}
+
+func TestGroupBySeqSpill(t *testing.T) {
+	type emitted struct {
+		key   byte
+		group []string
+	}
+	firstLetter := func(s string) byte { return s[0] }
+
+	tests := []struct {
+		name        string
+		items       []string
+		maxBuffered int
+		emitErr     error
+		want        []emitted
+		wantErr     error
+	}{
+		{
+			name:  "empty",
+			items: nil,
+		},
+		{
+			name:        "emits groups in order",
+			items:       []string{"apple", "avocado", "banana", "cherry", "cranberry"},
+			maxBuffered: 2,
+			want: []emitted{
+				{'a', []string{"apple", "avocado"}},
+				{'b', []string{"banana"}},
+				{'c', []string{"cherry", "cranberry"}},
+			},
+		},
+		{
+			name:        "unsorted input",
+			items:       []string{"apple", "banana", "avocado"},
+			maxBuffered: 10,
+			wantErr:     grab.ErrUnsortedInput,
+		},
+		{
+			name:        "emit error",
+			items:       []string{"apple", "banana"},
+			maxBuffered: 0,
+			emitErr:     errors.New("mock"),
+			wantErr:     errors.New("mock"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []emitted
+			err := grab.GroupBySeqSpill(slices.Values(tt.items), firstLetter, tt.maxBuffered, func(key byte, group []string) error {
+				if tt.emitErr != nil {
+					return tt.emitErr
+				}
+				got = append(got, emitted{key, group})
+				return nil
+			})
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupBySeqSpillBuffersUpToThreshold(t *testing.T) {
+	items := []string{"a1", "b1", "c1", "d1", "e1"}
+	yielded := 0
+	seq := func(yield func(string) bool) {
+		for _, item := range items {
+			yielded++
+			if !yield(item) {
+				return
+			}
+		}
+	}
+
+	// record how many items had been read from the sequence when each group was emitted
+	var readAtEmit []int
+	err := grab.GroupBySeqSpill(seq, func(s string) byte { return s[0] }, 2, func(key byte, group []string) error {
+		readAtEmit = append(readAtEmit, yielded)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 3, 5, 5, 5}, readAtEmit)
+}
@@ -0,0 +1,52 @@
+package grab
+
+import "context"
+
+// Signal broadcasts values to any number of waiting goroutines, and caches the last value broadcast.
+// It is safe for concurrent use.
+//
+// Example:
+// refreshed := NewSignal[Inventory]()
+//
+//	// in the background refresh loop
+//	refreshed.Broadcast(inventory)
+//
+//	// in a request handler which needs fresh data
+//	inventory, err := refreshed.Wait(ctx)
+//
+// Note: This type is useful for "data refreshed" notifications, where handlers need to wait for the next update
+// without polling. Use Watched to observe every change to a value over time.
+type Signal[T any] struct {
+	w *Watched[T]
+}
+
+// NewSignal creates a Signal which has not broadcast any value.
+func NewSignal[T any]() *Signal[T] {
+	var zero T
+	return &Signal[T]{w: NewWatched(zero)}
+}
+
+// Broadcast caches the value and wakes every goroutine blocked in Wait.
+func (s *Signal[T]) Broadcast(value T) {
+	s.w.Set(value)
+}
+
+// Last returns the last value broadcast. It returns false if no value has been broadcast yet.
+func (s *Signal[T]) Last() (T, bool) {
+	value, version := s.w.Get()
+	return value, version > 0
+}
+
+// Wait blocks until the next call to Broadcast and returns the value broadcast,
+// or returns the context error if the context is cancelled first.
+func (s *Signal[T]) Wait(ctx context.Context) (T, error) {
+	_, version := s.w.Get()
+	select {
+	case <-s.w.Changed(version):
+		value, _ := s.w.Get()
+		return value, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
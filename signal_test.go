@@ -0,0 +1,52 @@
+package grab_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignal(t *testing.T) {
+	sig := grab.NewSignal[int]()
+
+	_, ok := sig.Last()
+	assert.False(t, ok)
+
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	waiting := make(chan struct{}, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			waiting <- struct{}{}
+			results[i], _ = sig.Wait(context.Background())
+		}()
+	}
+	for range results {
+		<-waiting
+	}
+	// give the waiters time to block in Wait after signalling that they are about to
+	time.Sleep(10 * time.Millisecond)
+	sig.Broadcast(42)
+	wg.Wait()
+
+	assert.Equal(t, []int{42, 42, 42}, results)
+	last, ok := sig.Last()
+	assert.True(t, ok)
+	assert.Equal(t, 42, last)
+}
+
+func TestSignalWaitCancelled(t *testing.T) {
+	sig := grab.NewSignal[string]()
+	sig.Broadcast("stale")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := sig.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Wait should not return a value broadcast before it was called")
+}
@@ -0,0 +1,203 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+)
+
+// ItemError records an item which could not be written, and why.
+type ItemError[T any] struct {
+	// Item is the item which could not be written.
+	Item T
+	// Err is the error returned for the item.
+	Err error
+}
+
+func (e ItemError[T]) Error() string {
+	return fmt.Sprintf("item %v: %v", e.Item, e.Err)
+}
+
+func (e ItemError[T]) Unwrap() error {
+	return e.Err
+}
+
+// WriteError is returned by Sink.WriteAll when some items could not be written.
+type WriteError[T any] struct {
+	// Failed contains every item which could not be written, grouped by batch in the order the items were provided.
+	Failed []ItemError[T]
+}
+
+func (e *WriteError[T]) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("failed to write 1 item: %v", e.Failed[0].Err)
+	}
+	return fmt.Sprintf("failed to write %d items, first error: %v", len(e.Failed), e.Failed[0].Err)
+}
+
+// Unwrap returns the error for each failed item, so errors.Is and errors.As match any of them.
+func (e *WriteError[T]) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Items returns the items which could not be written, such as to send them to a dead-letter queue.
+func (e *WriteError[T]) Items() []T {
+	return Map(e.Failed, func(f ItemError[T]) T { return f.Item })
+}
+
+// Sink writes items to a destination which accepts them in batches, such as an SQS queue,
+// a DynamoDB table or an HTTP bulk endpoint. It is the write-side counterpart of AllPages.
+//
+// Example:
+//
+//	sink := Sink[Message]{
+//	    BatchSize:   10,
+//	    Concurrency: 4,
+//	    WriteBatch: func(ctx context.Context, batch []Message) ([]ItemError[Message], error) {
+//	        out, err := sqsClient.SendMessageBatch(ctx, toBatchInput(batch))
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return failedEntries(batch, out.Failed), nil
+//	    },
+//	}
+//	err := sink.WriteAll(ctx, messages)
+type Sink[T any] struct {
+	// WriteBatch writes a batch of items. It returns an error if the whole batch failed,
+	// or an ItemError for each item which failed if the batch was partially written.
+	WriteBatch func(ctx context.Context, batch []T) ([]ItemError[T], error)
+	// BatchSize is the maximum number of items passed to WriteBatch. Defaults to 25.
+	// Set it to the largest batch the destination accepts.
+	BatchSize int
+	// Concurrency is the maximum number of calls to WriteBatch running at once. Defaults to 4.
+	Concurrency int
+	// Retry controls how failed batches and items are retried. Items which fail with an error the policy
+	// doesn't retry are reported immediately, and the rest are retried together in a smaller batch.
+	Retry RetryPolicy
+	// DeadLetter collects the items which could not be written, if set.
+	// WriteAll then returns nil rather than a *WriteError when items fail.
+	DeadLetter *DeadLetter[T]
+	// Ledger records the keys of the items which have been written, if set, so that items written by
+	// an earlier, interrupted call to WriteAll are skipped. Key must be set if Ledger is set.
+	Ledger *Ledger[string]
+	// Key returns the key identifying an item in the Ledger.
+	Key func(T) string
+}
+
+// WriteAll splits the items into batches and writes them concurrently, retrying failures according to the Retry policy.
+// A failed batch or item doesn't stop the remaining batches from being written.
+//
+// Parameters:
+//   - ctx: A context.Context used for cancellation. It is passed to WriteBatch.
+//   - items: The items to write.
+//   - opts: Optional settings passed to Retry, such as WithJitter and WithClock.
+//
+// Returns:
+//   - error: A *WriteError listing every item which could not be written, or the context error
+//     if the context was cancelled. It returns nil if every item was written, or if the failed items
+//     were added to the DeadLetter.
+func (s *Sink[T]) WriteAll(ctx context.Context, items []T, opts ...Option[Config]) error {
+	batchSize := s.BatchSize
+	if batchSize <= 0 {
+		batchSize = 25
+	}
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	if s.Ledger != nil {
+		var err error
+		items, err = Unprocessed(ctx, s.Ledger, items, s.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	batches := Chunk(items, batchSize)
+	failed := make([][]DeadLetterEntry[T], len(batches))
+	err := doIndexed(ctx, concurrency, batches, func(ctx context.Context, i int, batch []T) error {
+		failed[i] = s.writeBatch(ctx, batch, opts)
+		return s.markWritten(ctx, batch, failed[i])
+	})
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var all []ItemError[T]
+	for _, entries := range failed {
+		for _, entry := range entries {
+			if s.DeadLetter != nil {
+				s.DeadLetter.Add(entry.Item, entry.Err, entry.Attempts)
+			} else {
+				all = append(all, ItemError[T]{Item: entry.Item, Err: entry.Err})
+			}
+		}
+	}
+	if len(all) > 0 {
+		return &WriteError[T]{Failed: all}
+	}
+	return nil
+}
+
+// writeBatch writes a batch, retrying the whole batch or the failed items, and returns the items which could not be written.
+func (s *Sink[T]) writeBatch(ctx context.Context, batch []T, opts []Option[Config]) []DeadLetterEntry[T] {
+	policy := s.Retry.withDefaults()
+	pending := Map(batch, func(item T) ItemError[T] { return ItemError[T]{Item: item} })
+	var failed []DeadLetterEntry[T]
+	attempts := 0
+
+	_, err := Retry(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		attempts++
+		itemErrs, err := s.WriteBatch(ctx, Map(pending, func(p ItemError[T]) T { return p.Item }))
+		if err != nil {
+			for i := range pending {
+				pending[i].Err = err
+			}
+			return struct{}{}, err
+		}
+
+		pending = pending[:0:0]
+		for _, ie := range itemErrs {
+			if ie.Err == nil {
+				continue
+			}
+			if policy.ShouldRetry(ie.Err) {
+				pending = append(pending, ie)
+			} else {
+				failed = append(failed, DeadLetterEntry[T]{Item: ie.Item, Err: ie.Err, Attempts: attempts})
+			}
+		}
+		if len(pending) > 0 {
+			// return the first item's error so that Retry can honour any throttling hint it carries
+			return struct{}{}, pending[0].Err
+		}
+		return struct{}{}, nil
+	}, opts...)
+
+	if err != nil {
+		for _, p := range pending {
+			failed = append(failed, DeadLetterEntry[T]{Item: p.Item, Err: p.Err, Attempts: attempts})
+		}
+	}
+	return failed
+}
+
+// markWritten records the items in the batch which didn't fail in the Ledger, if one is set.
+func (s *Sink[T]) markWritten(ctx context.Context, batch []T, failed []DeadLetterEntry[T]) error {
+	if s.Ledger == nil {
+		return nil
+	}
+	failedKeys := MapFromSlice(Map(failed, func(e DeadLetterEntry[T]) string { return s.Key(e.Item) }), struct{}{})
+	written := Filter(Map(batch, s.Key), func(key string) bool {
+		_, ok := failedKeys[key]
+		return !ok
+	})
+	return s.Ledger.MarkProcessed(ctx, written...)
+}
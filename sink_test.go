@@ -0,0 +1,133 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinkWriteAll(t *testing.T) {
+	errTransient := errors.New("transient")
+	errInvalid := errors.New("invalid")
+
+	tests := []struct {
+		name        string
+		items       []int
+		writeBatch  func(call int, batch []int) ([]grab.ItemError[int], error)
+		wantErr     bool
+		wantFailed  []int
+		wantBatches [][]int
+	}{
+		{
+			name:  "all written",
+			items: seqInts(5),
+			writeBatch: func(call int, batch []int) ([]grab.ItemError[int], error) {
+				return nil, nil
+			},
+			wantBatches: [][]int{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			name:  "failed items are retried in a smaller batch",
+			items: seqInts(2),
+			writeBatch: func(call int, batch []int) ([]grab.ItemError[int], error) {
+				if call == 1 {
+					return []grab.ItemError[int]{{Item: 1, Err: errTransient}}, nil
+				}
+				return nil, nil
+			},
+			wantBatches: [][]int{{0, 1}, {1}},
+		},
+		{
+			name:  "non-retryable items are reported immediately",
+			items: seqInts(2),
+			writeBatch: func(call int, batch []int) ([]grab.ItemError[int], error) {
+				return []grab.ItemError[int]{{Item: 0, Err: &grab.HTTPStatusError{StatusCode: 404}}}, nil
+			},
+			wantErr:     true,
+			wantFailed:  []int{0},
+			wantBatches: [][]int{{0, 1}},
+		},
+		{
+			name:  "whole batch failure reports every item",
+			items: seqInts(3),
+			writeBatch: func(call int, batch []int) ([]grab.ItemError[int], error) {
+				if batch[0] == 2 {
+					return nil, errInvalid
+				}
+				return nil, nil
+			},
+			wantErr:     true,
+			wantFailed:  []int{2},
+			wantBatches: [][]int{{0, 1}, {2}, {2}, {2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var batches [][]int
+
+			sink := grab.Sink[int]{
+				BatchSize:   2,
+				Concurrency: 1,
+				WriteBatch: func(ctx context.Context, batch []int) ([]grab.ItemError[int], error) {
+					mu.Lock()
+					batches = append(batches, slices.Clone(batch))
+					call := len(batches)
+					mu.Unlock()
+					return tt.writeBatch(call, batch)
+				},
+			}
+			clock := &instantClock{Clock: grab.NewFakeClock(epoch)}
+			err := sink.WriteAll(context.Background(), tt.items, grab.WithClock(clock))
+
+			assert.Equal(t, tt.wantBatches, batches)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var writeErr *grab.WriteError[int]
+			if assert.ErrorAs(t, err, &writeErr) {
+				assert.Equal(t, tt.wantFailed, writeErr.Items())
+			}
+		})
+	}
+}
+
+func TestSinkWriteAllHonoursThrottling(t *testing.T) {
+	var calls int
+	sink := grab.Sink[string]{
+		WriteBatch: func(ctx context.Context, batch []string) ([]grab.ItemError[string], error) {
+			calls++
+			if calls == 1 {
+				return []grab.ItemError[string]{{Item: "b", Err: throttleError{after: 2 * time.Second}}}, nil
+			}
+			return nil, nil
+		},
+	}
+	clock := &instantClock{Clock: grab.NewFakeClock(epoch)}
+
+	err := sink.WriteAll(context.Background(), []string{"a", "b"}, grab.WithClock(clock))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second}, clock.waits)
+}
+
+func TestSinkWriteAllCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sink := grab.Sink[int]{
+		WriteBatch: func(ctx context.Context, batch []int) ([]grab.ItemError[int], error) {
+			return nil, nil
+		},
+	}
+	err := sink.WriteAll(ctx, seqInts(3))
+	assert.ErrorIs(t, err, context.Canceled)
+}
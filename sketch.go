@@ -0,0 +1,162 @@
+package grab
+
+import (
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// Sketch is a count-min sketch, which estimates how many times each item has been added using a fixed amount
+// of memory. Estimates never undercount, and overcount by at most epsilon times the total count with
+// probability 1-delta.
+// It is safe for concurrent use.
+//
+// Example:
+// requests := NewSketch[string](0.001, 0.01)
+// requests.Add(userID, 1)
+// approx := requests.Count(userID)
+//
+// Note: This type is useful for frequency counting over event streams too large for an exact CountBy map.
+type Sketch[T comparable] struct {
+	width  uint64
+	depth  uint64
+	counts []atomic.Uint64 // depth rows of width counters
+	seed1  maphash.Seed
+	seed2  maphash.Seed
+}
+
+// NewSketch creates an empty count-min sketch.
+//
+// Parameters:
+//   - epsilon: The maximum overcount, as a fraction of the total count. Values outside (0, 1) are treated as 0.001.
+//   - delta: The probability that an estimate exceeds the epsilon bound. Values outside (0, 1) are treated as 0.01.
+//
+// Returns:
+//   - *Sketch[T]: An empty Sketch.
+func NewSketch[T comparable](epsilon, delta float64) *Sketch[T] {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return &Sketch[T]{
+		width:  width,
+		depth:  depth,
+		counts: make([]atomic.Uint64, width*depth),
+		seed1:  maphash.MakeSeed(),
+		seed2:  maphash.MakeSeed(),
+	}
+}
+
+// Add increases the count for an item.
+func (s *Sketch[T]) Add(item T, count uint64) {
+	h1, h2 := maphash.Comparable(s.seed1, item), maphash.Comparable(s.seed2, item)|1
+	for row := uint64(0); row < s.depth; row++ {
+		s.counts[row*s.width+(h1+row*h2)%s.width].Add(count)
+	}
+}
+
+// Count returns the estimated count for an item.
+func (s *Sketch[T]) Count(item T) uint64 {
+	h1, h2 := maphash.Comparable(s.seed1, item), maphash.Comparable(s.seed2, item)|1
+	estimate := uint64(math.MaxUint64)
+	for row := uint64(0); row < s.depth; row++ {
+		estimate = min(estimate, s.counts[row*s.width+(h1+row*h2)%s.width].Load())
+	}
+	return estimate
+}
+
+// Cardinality is a HyperLogLog estimator of the number of distinct items added to it,
+// using a fixed amount of memory.
+// It is safe for concurrent use.
+//
+// Example:
+// users := NewCardinality[string](14)
+//
+//	for event := range events {
+//	    users.Add(event.UserID)
+//	}
+//
+// distinct := users.Estimate()
+//
+// Note: This type is useful for counting distinct users or resources in event streams too large
+// to deduplicate exactly.
+type Cardinality[T comparable] struct {
+	mu        sync.Mutex
+	precision uint8
+	registers []uint8
+	seed      maphash.Seed
+}
+
+// NewCardinality creates an empty HyperLogLog estimator.
+//
+// Parameters:
+//   - precision: The number of bits used to select a register, between 4 and 16. Higher values use
+//     2^precision bytes of memory and give a standard error of roughly 1.04/sqrt(2^precision).
+//     Values outside the range are clamped.
+//
+// Returns:
+//   - *Cardinality[T]: An empty estimator.
+func NewCardinality[T comparable](precision uint8) *Cardinality[T] {
+	precision = max(4, min(16, precision))
+	return &Cardinality[T]{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+		seed:      maphash.MakeSeed(),
+	}
+}
+
+// Add records an item.
+func (c *Cardinality[T]) Add(item T) {
+	h := maphash.Comparable(c.seed, item)
+	idx := h >> (64 - c.precision)
+	// the sentinel bit bounds the rank when the remaining bits are all zero
+	rank := uint8(bits.LeadingZeros64(h<<c.precision|1<<(c.precision-1))) + 1
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rank > c.registers[idx] {
+		c.registers[idx] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct items added.
+func (c *Cardinality[T]) Estimate() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m := float64(len(c.registers))
+	var sum float64
+	zeros := 0
+	for _, r := range c.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+func hllAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
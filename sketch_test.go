@@ -0,0 +1,57 @@
+package grab_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch(t *testing.T) {
+	s := grab.NewSketch[string](0.001, 0.01)
+	var total uint64
+	for i := 0; i < 1000; i++ {
+		s.Add(fmt.Sprintf("item-%d", i), uint64(i%10+1))
+		total += uint64(i%10 + 1)
+	}
+	s.Add("hot", 500)
+	total += 500
+
+	assert.GreaterOrEqual(t, s.Count("hot"), uint64(500), "counts are never underestimated")
+	assert.LessOrEqual(t, s.Count("hot"), uint64(500)+uint64(0.001*float64(total))+1)
+	assert.GreaterOrEqual(t, s.Count("item-9"), uint64(10))
+	assert.LessOrEqual(t, s.Count("missing"), uint64(0.001*float64(total))+1)
+}
+
+func TestCardinality(t *testing.T) {
+	tests := []struct {
+		name     string
+		distinct int
+	}{
+		{
+			name:     "empty",
+			distinct: 0,
+		},
+		{
+			name:     "small",
+			distinct: 100,
+		},
+		{
+			name:     "large",
+			distinct: 200000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := grab.NewCardinality[int](14)
+			for repeat := 0; repeat < 3; repeat++ {
+				for i := 0; i < tt.distinct; i++ {
+					c.Add(i)
+				}
+			}
+			// the standard error at precision 14 is under 1%, so allow a generous 5%
+			assert.InDelta(t, tt.distinct, c.Estimate(), float64(tt.distinct)*0.05+1)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package grab
+
+// Slab hands out pointers to values stored in large, shared backing arrays, reducing the number of
+// allocations when creating many small values on hot paths.
+// The zero value is ready to use with a default chunk size. It is not safe for concurrent use.
+//
+// Example:
+// var slab Slab[string]
+//
+//	for _, u := range users {
+//	    input.Names = append(input.Names, slab.New(u.Name))
+//	}
+//
+// Note: A backing array is kept alive for as long as any pointer into it is reachable, so a Slab is best
+// suited to values with similar lifetimes, such as the pointers in a single SDK request.
+type Slab[T any] struct {
+	// ChunkSize is the number of values allocated in each backing array. Defaults to 1024.
+	ChunkSize int
+
+	chunk []T
+}
+
+// New returns a pointer to a copy of the value, stored in the Slab's current backing array.
+func (s *Slab[T]) New(value T) *T {
+	if len(s.chunk) == cap(s.chunk) {
+		size := s.ChunkSize
+		if size <= 0 {
+			size = 1024
+		}
+		s.chunk = make([]T, 0, size)
+	}
+	s.chunk = append(s.chunk, value)
+	return &s.chunk[len(s.chunk)-1]
+}
@@ -0,0 +1,32 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlab(t *testing.T) {
+	slab := grab.Slab[int]{ChunkSize: 2}
+	var ptrs []*int
+	for i := 0; i < 5; i++ {
+		ptrs = append(ptrs, slab.New(i))
+	}
+	for i, p := range ptrs {
+		assert.Equal(t, i, *p)
+	}
+
+	// pointers stay valid when a new chunk is allocated
+	*ptrs[0] = 100
+	assert.Equal(t, 1, *ptrs[1])
+	assert.Equal(t, 100, *ptrs[0])
+}
+
+func TestSlabDefaultChunkSize(t *testing.T) {
+	var slab grab.Slab[string]
+	a := slab.New("a")
+	b := slab.New("b")
+	assert.Equal(t, "a", *a)
+	assert.Equal(t, "b", *b)
+}
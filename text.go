@@ -0,0 +1,310 @@
+package grab
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FindClosestBy returns the item whose key is closest to 'query' by Levenshtein distance,
+// for "did you mean" suggestions when a user-typed name doesn't match exactly.
+// If several items are equally close, the first one is returned.
+//
+// Parameters:
+//   - items: A slice of items of type 'T' to search.
+//   - query: The string to match against.
+//   - keyFn: A function that returns the string to compare for each item, such as its name.
+//   - maxDistance: The largest number of single-character edits for an item to be considered a match.
+//
+// Returns:
+//   - T: The closest item, or the zero value of 'T' if no item is within 'maxDistance'.
+//   - bool: True if a match was found.
+//
+// Example:
+//
+//	if _, ok := Find(accounts, nameIs(input)); !ok {
+//	    if closest, ok := FindClosestBy(accounts, input, func(a Account) string { return a.Name }, 3); ok {
+//	        return fmt.Errorf("account %q not found, did you mean %q?", input, closest.Name)
+//	    }
+//	}
+//
+// Note: The comparison is case-sensitive and measured in runes. Items whose key length differs from
+// the query by more than 'maxDistance' are skipped without computing the distance, so large listings are cheap to search.
+func FindClosestBy[T any](items []T, query string, keyFn func(T) string, maxDistance int) (T, bool) {
+	var best T
+	found := false
+	q := []rune(query)
+	for _, item := range items {
+		d, ok := levenshtein(q, []rune(keyFn(item)), maxDistance)
+		if !ok {
+			continue
+		}
+		best, found = item, true
+		if d == 0 {
+			break
+		}
+		// only accept strictly closer items from now on, so the first of equally close items wins
+		maxDistance = d - 1
+	}
+	return best, found
+}
+
+// levenshtein returns the edit distance between 'a' and 'b', and false if it is greater than 'limit'.
+func levenshtein(a, b []rune, limit int) (int, bool) {
+	if limit < 0 || abs(len(a)-len(b)) > limit {
+		return 0, false
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	// keep two rows of the DP table, sized by the shorter string
+	prev := make([]int, len(a)+1)
+	curr := make([]int, len(a)+1)
+	for i := range prev {
+		prev[i] = i
+	}
+	for j := 1; j <= len(b); j++ {
+		curr[0] = j
+		rowMin := curr[0]
+		for i := 1; i <= len(a); i++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[i] = min(prev[i]+1, curr[i-1]+1, prev[i-1]+cost)
+			rowMin = min(rowMin, curr[i])
+		}
+		if rowMin > limit {
+			// every later row is at least as large, so the limit can't be met
+			return 0, false
+		}
+		prev, curr = curr, prev
+	}
+
+	d := prev[len(a)]
+	return d, d <= limit
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// NaturalCompare compares two strings in natural order, where runs of digits are compared by their numeric value,
+// so that "server2" sorts before "server10". Other characters are compared by their code points.
+// It returns -1 if 'a' sorts before 'b', 1 if it sorts after, and 0 if they are equal, so it can be passed to slices.SortFunc.
+//
+// Example:
+//
+//	names := []string{"server10", "server2", "server1"}
+//	slices.SortFunc(names, NaturalCompare)
+//	// names is now ["server1", "server2", "server10"]
+//
+// Note: Numbers with the same value but different leading zeros, such as "07" and "7", are ordered by
+// plain string comparison, so the ordering is total. Signs and decimal points are not treated as part of a number.
+func NaturalCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			// compare the digit runs by value: ignore leading zeros, then a longer run is a larger number
+			si, sj := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			na := strings.TrimLeft(a[si:i], "0")
+			nb := strings.TrimLeft(b[sj:j], "0")
+			if len(na) != len(nb) {
+				if len(na) < len(nb) {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		ra, wa := utf8.DecodeRuneInString(a[i:])
+		rb, wb := utf8.DecodeRuneInString(b[j:])
+		if ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+		i += wa
+		j += wb
+	}
+
+	switch {
+	case i < len(a):
+		return 1
+	case j < len(b):
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// NaturalLess reports whether 'a' sorts before 'b' in natural order. See NaturalCompare.
+func NaturalLess(a, b string) bool {
+	return NaturalCompare(a, b) < 0
+}
+
+// CaseInsensitiveCompare compares two strings ignoring case, without allocating lower-cased copies.
+// Strings which differ only by case are ordered by plain string comparison, so the ordering is total.
+// It returns -1, 0 or 1, so it can be passed to slices.SortFunc.
+func CaseInsensitiveCompare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, wa := utf8.DecodeRuneInString(a[i:])
+		rb, wb := utf8.DecodeRuneInString(b[j:])
+		if ra != rb {
+			la, lb := unicode.ToLower(ra), unicode.ToLower(rb)
+			if la != lb {
+				if la < lb {
+					return -1
+				}
+				return 1
+			}
+		}
+		i += wa
+		j += wb
+	}
+
+	switch {
+	case i < len(a):
+		return 1
+	case j < len(b):
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// CaseInsensitiveLess reports whether 'a' sorts before 'b' when case is ignored. See CaseInsensitiveCompare.
+func CaseInsensitiveLess(a, b string) bool {
+	return CaseInsensitiveCompare(a, b) < 0
+}
+
+// JoinBy formats each item with 'fn' and joins the results with 'sep', such as to summarize typed slices
+// in log lines and error messages without building an intermediate []string.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - sep: The separator placed between items.
+//   - fn: A function that formats one item.
+//
+// Returns:
+//   - string: The joined string, or an empty string if 'items' is empty.
+//
+// Example:
+//
+//	msg := JoinBy(grants, ", ", func(g Grant) string { return g.ID + " (" + g.Status + ")" })
+//	// msg will be "gr_1 (active), gr_2 (expired)"
+func JoinBy[T any](items []T, sep string, fn func(T) string) string {
+	var b strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(fn(item))
+	}
+	return b.String()
+}
+
+// Lines joins the strings with newlines, for multi-line summaries. There is no trailing newline.
+//
+// Example:
+//
+//	fmt.Println(Lines([]string{"created: 2", "updated: 5"}))
+func Lines(lines []string) string {
+	return strings.Join(lines, "\n")
+}
+
+// Summarize formats at most 'limit' items, joined with ", ", followed by the number of items left out,
+// such as "a, b, c … and 42 more". It is intended for logging large results, such as the output of AllPages,
+// without dumping every entry.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - limit: The maximum number of items to format. If it is less than 1, only the number of items is returned, such as "45 items".
+//   - fn: A function that formats one item.
+//
+// Returns:
+//   - string: The summary. If there are no more than 'limit' items, it is the same as JoinBy(items, ", ", fn).
+//
+// Example:
+//
+//	users, err := AllPages(ctx, listUsers)
+//	log.Info("synced users", "users", Summarize(users, 3, func(u User) string { return u.Email }))
+//	// users="alice@example.com, bob@example.com, carol@example.com … and 42 more"
+func Summarize[T any](items []T, limit int, fn func(T) string) string {
+	if limit < 1 {
+		return fmt.Sprintf("%d items", len(items))
+	}
+	summary := JoinBy(Take(items, limit), ", ", fn)
+	if rest := len(items) - limit; rest > 0 {
+		summary += fmt.Sprintf(" … and %d more", rest)
+	}
+	return summary
+}
+
+// MaskMiddle replaces the middle of 's' with '*', keeping 'keep' runes at each end, for partially redacting
+// identifiers in logs and summaries. The masked string has the same number of runes as 's', so masked values
+// still line up in tables and diffs.
+//
+// Parameters:
+//   - s: The string to mask.
+//   - keep: The number of runes to keep at the start and at the end. If 's' is too short to keep both ends
+//     and still mask something, every rune is masked.
+//
+// Returns:
+//   - string: The masked string.
+//
+// Example:
+//
+//	MaskMiddle("123456789012", 2)       // "12********12"
+//	MaskMiddle("alice@example.com", 3) // "ali***********com"
+func MaskMiddle(s string, keep int) string {
+	runes := []rune(s)
+	keep = max(keep, 0)
+	if len(runes) <= 2*keep {
+		keep = 0
+	}
+	for i := keep; i < len(runes)-keep; i++ {
+		runes[i] = '*'
+	}
+	return string(runes)
+}
+
+// MaskBy returns the string for each item with its middle masked by MaskMiddle, such as to log which
+// accounts were affected without printing their full IDs.
+//
+// Parameters:
+//   - items: A slice of items of type 'T'.
+//   - keep: The number of runes to keep at each end, as for MaskMiddle.
+//   - fn: A function that returns the string to mask for each item, such as its ID.
+//
+// Returns:
+//   - []string: The masked strings, in the same order as 'items'.
+//
+// Example:
+//
+//	masked := MaskBy(accounts, 2, func(a Account) string { return a.ID })
+//	log.Info("accounts affected", "accounts", strings.Join(masked, ", "))
+func MaskBy[T any](items []T, keep int, fn func(T) string) []string {
+	return Map(items, func(item T) string {
+		return MaskMiddle(fn(item), keep)
+	})
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,163 @@
+package grab_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindClosestBy(t *testing.T) {
+	type account struct{ Name string }
+	accounts := []account{{Name: "prod-billing"}, {Name: "prod-web"}, {Name: "dev-web"}, {Name: "staging"}}
+	name := func(a account) string { return a.Name }
+
+	tests := []struct {
+		name        string
+		query       string
+		maxDistance int
+		want        account
+		wantOK      bool
+	}{
+		{name: "exact match", query: "prod-web", maxDistance: 2, want: account{Name: "prod-web"}, wantOK: true},
+		{name: "typo", query: "prod-biling", maxDistance: 2, want: account{Name: "prod-billing"}, wantOK: true},
+		{name: "closest wins", query: "dev-wab", maxDistance: 3, want: account{Name: "dev-web"}, wantOK: true},
+		{name: "first of equally close wins", query: "pxx-web", maxDistance: 3, want: account{Name: "prod-web"}, wantOK: true},
+		{name: "too far", query: "production", maxDistance: 2, wantOK: false},
+		{name: "negative distance", query: "staging", maxDistance: -1, wantOK: false},
+		{name: "unicode", query: "stagíng", maxDistance: 1, want: account{Name: "staging"}, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := grab.FindClosestBy(accounts, tt.query, name, tt.maxDistance)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "numbers by value", a: "server2", b: "server10", want: -1},
+		{name: "numbers by value reversed", a: "server10", b: "server2", want: 1},
+		{name: "equal", a: "server10", b: "server10", want: 0},
+		{name: "several numbers", a: "v1.10.2", b: "v1.9.12", want: 1},
+		{name: "prefix sorts first", a: "server", b: "server1", want: -1},
+		{name: "text before number", a: "a1", b: "b0", want: -1},
+		{name: "leading zeros ordered by string", a: "file07", b: "file7", want: -1},
+		{name: "leading zeros equal value", a: "file007b", b: "file7a", want: 1},
+		{name: "case-sensitive", a: "B", b: "a", want: -1},
+		{name: "empty", a: "", b: "a", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.NaturalCompare(tt.a, tt.b))
+			assert.Equal(t, tt.want < 0, grab.NaturalLess(tt.a, tt.b))
+		})
+	}
+}
+
+func TestCaseInsensitiveCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "ignores case", a: "apple", b: "Banana", want: -1},
+		{name: "ignores case reversed", a: "Banana", b: "apple", want: 1},
+		{name: "differs only by case", a: "Apple", b: "apple", want: -1},
+		{name: "equal", a: "apple", b: "apple", want: 0},
+		{name: "prefix sorts first", a: "APP", b: "apple", want: -1},
+		{name: "unicode", a: "Ärger", b: "ärgern", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.CaseInsensitiveCompare(tt.a, tt.b))
+			assert.Equal(t, tt.want < 0, grab.CaseInsensitiveLess(tt.a, tt.b))
+		})
+	}
+}
+
+func TestJoinBy(t *testing.T) {
+	type grant struct {
+		ID     string
+		Status string
+	}
+	format := func(g grant) string { return g.ID + " (" + g.Status + ")" }
+
+	tests := []struct {
+		name  string
+		items []grant
+		sep   string
+		want  string
+	}{
+		{name: "several items", items: []grant{{"gr_1", "active"}, {"gr_2", "expired"}}, sep: ", ", want: "gr_1 (active), gr_2 (expired)"},
+		{name: "one item", items: []grant{{"gr_1", "active"}}, sep: ", ", want: "gr_1 (active)"},
+		{name: "empty", items: nil, sep: ", ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.JoinBy(tt.items, tt.sep, format))
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	assert.Equal(t, "created: 2\nupdated: 5", grab.Lines([]string{"created: 2", "updated: 5"}))
+	assert.Equal(t, "", grab.Lines(nil))
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []string
+		limit int
+		want  string
+	}{
+		{name: "fewer than max", items: []string{"a", "b"}, limit: 3, want: "A, B"},
+		{name: "exactly max", items: []string{"a", "b", "c"}, limit: 3, want: "A, B, C"},
+		{name: "more than max", items: []string{"a", "b", "c", "d", "e"}, limit: 3, want: "A, B, C … and 2 more"},
+		{name: "empty", items: nil, limit: 3, want: ""},
+		{name: "zero max", items: []string{"a", "b"}, limit: 0, want: "2 items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.Summarize(tt.items, tt.limit, strings.ToUpper))
+		})
+	}
+}
+
+func TestMaskMiddle(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		keep int
+		want string
+	}{
+		{name: "account ID", s: "123456789012", keep: 2, want: "12********12"},
+		{name: "email", s: "alice@example.com", keep: 3, want: "ali***********com"},
+		{name: "too short to keep ends", s: "abcd", keep: 2, want: "****"},
+		{name: "zero keep", s: "abc", keep: 0, want: "***"},
+		{name: "negative keep", s: "abc", keep: -1, want: "***"},
+		{name: "unicode", s: "zürich-01", keep: 2, want: "zü*****01"},
+		{name: "empty", s: "", keep: 2, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, grab.MaskMiddle(tt.s, tt.keep))
+		})
+	}
+}
+
+func TestMaskBy(t *testing.T) {
+	type account struct{ ID string }
+	accounts := []account{{ID: "123456789012"}, {ID: "210987654321"}}
+
+	got := grab.MaskBy(accounts, 2, func(a account) string { return a.ID })
+	assert.Equal(t, []string{"12********12", "21********21"}, got)
+}
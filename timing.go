@@ -0,0 +1,130 @@
+package grab
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timed calls a function and returns its result along with how long the call took.
+// It is a generic function that works with any result type 'T'.
+//
+// Parameters:
+//   - fn: The function to call and measure.
+//   - opts: Optional settings. WithClock sets the Clock used to measure the call.
+//
+// Returns:
+//   - T: The value returned by 'fn'.
+//   - time.Duration: How long the call to 'fn' took.
+//   - error: The error returned by 'fn'.
+//
+// Example:
+// users, took, err := Timed(func() ([]User, error) { return AllPages(ctx, listUsersPage) })
+//
+// Note: This function is useful for measuring latency without splitting the call site into
+// separate statements for recording the start time, making the call, and computing the duration.
+func Timed[T any](fn func() (T, error), opts ...Option[Config]) (T, time.Duration, error) {
+	clock := newConfig(opts).Clock
+	start := clock.Now()
+	result, err := fn()
+	return result, clock.Now().Sub(start), err
+}
+
+// TimedReport calls a function and reports how long the call took to a callback, such as a metric recorder.
+// The duration is reported whether or not 'fn' returns an error.
+//
+// Parameters:
+//   - fn: The function to call and measure.
+//   - report: A callback which receives the duration of the call and the error returned by 'fn'.
+//   - opts: Optional settings. WithClock sets the Clock used to measure the call.
+//
+// Returns:
+//   - T: The value returned by 'fn'.
+//   - error: The error returned by 'fn'.
+//
+// Example:
+//
+//	users, err := TimedReport(func() ([]User, error) {
+//	    return AllPages(ctx, listUsersPage)
+//	}, func(d time.Duration, err error) {
+//	    listUsersLatency.Observe(d.Seconds())
+//	})
+func TimedReport[T any](fn func() (T, error), report func(d time.Duration, err error), opts ...Option[Config]) (T, error) {
+	result, d, err := Timed(fn, opts...)
+	report(d, err)
+	return result, err
+}
+
+// Lap is a named phase recorded by a Stopwatch.
+type Lap struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Stopwatch records how long each named phase of a multi-stage job took.
+// It is safe for concurrent use.
+//
+// Example:
+// sw := NewStopwatch()
+// users, err := AllPages(ctx, listUsersPage)
+// sw.Lap("fetch")
+// rows := Map(users, toRow)
+// sw.Lap("transform")
+//
+// log.Println(sw) // fetch=1.2s transform=35ms total=1.235s
+type Stopwatch struct {
+	clock Clock
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	laps  []Lap
+}
+
+// NewStopwatch creates a Stopwatch which starts timing immediately.
+// WithClock sets the Clock used to measure the phases.
+func NewStopwatch(opts ...Option[Config]) *Stopwatch {
+	cfg := newConfig(opts)
+	now := cfg.Clock.Now()
+	return &Stopwatch{clock: cfg.Clock, start: now, last: now}
+}
+
+// Lap records a phase with the given name, covering the time since the previous lap
+// (or since the Stopwatch was created, for the first lap), and returns its duration.
+func (s *Stopwatch) Lap(name string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	d := now.Sub(s.last)
+	s.last = now
+	s.laps = append(s.laps, Lap{Name: name, Duration: d})
+	return d
+}
+
+// Laps returns a copy of the phases recorded so far, in the order they were recorded.
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Lap(nil), s.laps...)
+}
+
+// Total returns the time between creating the Stopwatch and the most recent lap.
+func (s *Stopwatch) Total() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last.Sub(s.start)
+}
+
+// String returns a summary of the recorded phases, suitable for logging, in the form
+// "fetch=1.2s transform=35ms total=1.235s".
+func (s *Stopwatch) String() string {
+	var b strings.Builder
+	for _, lap := range s.Laps() {
+		b.WriteString(lap.Name)
+		b.WriteString("=")
+		b.WriteString(lap.Duration.String())
+		b.WriteString(" ")
+	}
+	b.WriteString("total=")
+	b.WriteString(s.Total().String())
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package grab_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimed(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		mockErr error
+	}{
+		{
+			name:   "ok",
+			result: "ok",
+		},
+		{
+			name:    "error",
+			mockErr: errors.New("mock"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := grab.NewFakeClock(epoch)
+			got, took, err := grab.Timed(func() (string, error) {
+				clock.Advance(2 * time.Millisecond)
+				return tt.result, tt.mockErr
+			}, grab.WithClock(clock))
+			assert.Equal(t, tt.result, got)
+			assert.Equal(t, tt.mockErr, err)
+			assert.Equal(t, 2*time.Millisecond, took)
+		})
+	}
+
+	t.Run("real clock", func(t *testing.T) {
+		_, took, _ := grab.Timed(func() (string, error) {
+			time.Sleep(2 * time.Millisecond)
+			return "", nil
+		})
+		assert.GreaterOrEqual(t, took, 2*time.Millisecond)
+	})
+}
+
+func TestTimedReport(t *testing.T) {
+	var reported time.Duration
+	var reportedErr error
+	mockErr := errors.New("mock")
+	clock := grab.NewFakeClock(epoch)
+
+	got, err := grab.TimedReport(func() (int, error) {
+		clock.Advance(2 * time.Millisecond)
+		return 1, mockErr
+	}, func(d time.Duration, err error) {
+		reported = d
+		reportedErr = err
+	}, grab.WithClock(clock))
+	assert.Equal(t, 1, got)
+	assert.Equal(t, mockErr, err)
+	assert.Equal(t, mockErr, reportedErr)
+	assert.Equal(t, 2*time.Millisecond, reported)
+}
+
+func TestStopwatch(t *testing.T) {
+	clock := grab.NewFakeClock(epoch)
+	sw := grab.NewStopwatch(grab.WithClock(clock))
+	clock.Advance(2 * time.Second)
+	fetch := sw.Lap("fetch")
+	clock.Advance(35 * time.Millisecond)
+	transform := sw.Lap("transform")
+
+	assert.Equal(t, 2*time.Second, fetch)
+	assert.Equal(t, 35*time.Millisecond, transform)
+	assert.Equal(t, []grab.Lap{{Name: "fetch", Duration: fetch}, {Name: "transform", Duration: transform}}, sw.Laps())
+	assert.Equal(t, 2035*time.Millisecond, sw.Total())
+	assert.Equal(t, "fetch=2s transform=35ms total=2.035s", sw.String())
+}
@@ -0,0 +1,60 @@
+package grab
+
+import "context"
+
+// Tracer starts spans. It is intentionally minimal so that grab doesn't depend on a tracing library;
+// an OpenTelemetry trace.Tracer can be adapted to it in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, grab.Span) {
+//	    ctx, span := t.Tracer.Start(ctx, name)
+//	    return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation started by a Tracer.
+type Span interface {
+	// RecordError records that the operation failed with the given error.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// Traced runs a function inside a span, records any error it returns on the span, and returns its typed result.
+// It is a generic function that works with any result type 'T'.
+//
+// Parameters:
+//   - ctx: The parent context for the span.
+//   - tracer: The Tracer used to start the span. If nil, 'fn' is called without tracing.
+//   - name: The name of the span.
+//   - fn: The function to run. It receives the context containing the span.
+//
+// Returns:
+//   - T: The value returned by 'fn'.
+//   - error: The error returned by 'fn'.
+//
+// Example:
+//
+//	users, err := Traced(ctx, tracer, "list_users", func(ctx context.Context) ([]User, error) {
+//	    return AllPages(ctx, listUsersPage)
+//	})
+//
+// Note: This function removes the start/record/end boilerplate from call sites, and ensures the span
+// is always ended and errors are always recorded in a consistent way.
+func Traced[T any](ctx context.Context, tracer Tracer, name string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if tracer == nil {
+		return fn(ctx)
+	}
+
+	ctx, span := tracer.Start(ctx, name)
+	defer span.End()
+
+	result, err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return result, err
+}
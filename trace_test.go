@@ -0,0 +1,68 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSpan struct {
+	name  string
+	err   error
+	ended bool
+}
+
+func (s *mockSpan) RecordError(err error) { s.err = err }
+func (s *mockSpan) End()                  { s.ended = true }
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (t *mockTracer) Start(ctx context.Context, name string) (context.Context, grab.Span) {
+	span := &mockSpan{name: name}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestTraced(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  int
+		mockErr error
+	}{
+		{
+			name:   "ok",
+			result: 42,
+		},
+		{
+			name:    "error is recorded",
+			mockErr: errors.New("mock"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := &mockTracer{}
+			got, err := grab.Traced(context.Background(), tracer, "op", func(ctx context.Context) (int, error) {
+				return tt.result, tt.mockErr
+			})
+			assert.Equal(t, tt.result, got)
+			assert.Equal(t, tt.mockErr, err)
+			assert.Len(t, tracer.spans, 1)
+			assert.Equal(t, "op", tracer.spans[0].name)
+			assert.Equal(t, tt.mockErr, tracer.spans[0].err)
+			assert.True(t, tracer.spans[0].ended)
+		})
+	}
+}
+
+func TestTracedNilTracer(t *testing.T) {
+	got, err := grab.Traced(context.Background(), nil, "op", func(ctx context.Context) (string, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", got)
+}
@@ -0,0 +1,101 @@
+package grab
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// TransformRegistry holds named transformation functions, so that pipelines can be assembled at runtime
+// from configuration such as `transforms: [lowercase_arn, strip_tags]`.
+// It is safe for concurrent use, and the zero value is an empty TransformRegistry.
+//
+// Example:
+// var transforms TransformRegistry[Resource, Resource]
+// transforms.Register("lowercase_arn", lowercaseARN)
+// transforms.Register("strip_tags", stripTags)
+//
+// pipeline, err := ComposeTransforms(&transforms, cfg.Transforms...)
+// resources = Map(resources, pipeline)
+type TransformRegistry[T any, F any] struct {
+	mu         sync.RWMutex
+	transforms map[string]func(T) F
+}
+
+// Register stores a transform under a name, replacing any transform previously registered with that name.
+func (r *TransformRegistry[T, F]) Register(name string, fn func(T) F) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.transforms == nil {
+		r.transforms = make(map[string]func(T) F)
+	}
+	r.transforms[name] = fn
+}
+
+// Lookup returns the transform registered under a name.
+// It returns an error wrapping ErrNotRegistered if no transform has been registered with that name.
+func (r *TransformRegistry[T, F]) Lookup(name string) (func(T) F, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.transforms[name]
+	if !ok {
+		return nil, fmt.Errorf("transform %q: %w", name, ErrNotRegistered)
+	}
+	return fn, nil
+}
+
+// Names returns the names of the registered transforms, sorted alphabetically.
+func (r *TransformRegistry[T, F]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.transforms))
+	for name := range r.transforms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Apply looks up the transform registered under a name and applies it to each item with Map.
+// It returns an error wrapping ErrNotRegistered if no transform has been registered with that name.
+func (r *TransformRegistry[T, F]) Apply(name string, items []T) ([]F, error) {
+	fn, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return Map(items, fn), nil
+}
+
+// ComposeTransforms looks up the named transforms and composes them into a single function,
+// which applies the transforms in the order they are named.
+//
+// Parameters:
+//   - r: The registry to look the transforms up in. The transforms must return the same type they accept.
+//   - names: The names of the transforms to apply, in order. With no names, the composed function returns its input.
+//
+// Returns:
+//   - func(T) T: The composed transform.
+//   - error: An error wrapping ErrNotRegistered if any name has no registered transform.
+//
+// Example:
+// pipeline, err := ComposeTransforms(&transforms, "lowercase_arn", "strip_tags")
+// resources = Map(resources, pipeline)
+//
+// Note: The transforms are looked up when ComposeTransforms is called, so unknown names in configuration
+// are reported at startup rather than when the pipeline first runs.
+func ComposeTransforms[T any](r *TransformRegistry[T, T], names ...string) (func(T) T, error) {
+	fns := make([]func(T) T, len(names))
+	for i, name := range names {
+		fn, err := r.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		fns[i] = fn
+	}
+	return func(v T) T {
+		for _, fn := range fns {
+			v = fn(v)
+		}
+		return v
+	}, nil
+}
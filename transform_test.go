@@ -0,0 +1,56 @@
+package grab_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformRegistry(t *testing.T) {
+	var transforms grab.TransformRegistry[string, string]
+	transforms.Register("lowercase", strings.ToLower)
+	transforms.Register("trim", strings.TrimSpace)
+	transforms.Register("exclaim", func(s string) string { return s + "!" })
+
+	assert.Equal(t, []string{"exclaim", "lowercase", "trim"}, transforms.Names())
+
+	got, err := transforms.Apply("lowercase", []string{"A", "B"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, got)
+
+	_, err = transforms.Apply("missing", []string{"A"})
+	assert.ErrorIs(t, err, grab.ErrNotRegistered)
+	assert.EqualError(t, err, `transform "missing": not registered`)
+}
+
+func TestComposeTransforms(t *testing.T) {
+	var transforms grab.TransformRegistry[string, string]
+	transforms.Register("lowercase", strings.ToLower)
+	transforms.Register("trim", strings.TrimSpace)
+	transforms.Register("exclaim", func(s string) string { return s + "!" })
+
+	tests := []struct {
+		name    string
+		names   []string
+		want    string
+		wantErr error
+	}{
+		{name: "no transforms", names: nil, want: "  Hello "},
+		{name: "applied in order", names: []string{"trim", "exclaim", "lowercase"}, want: "hello!"},
+		{name: "order matters", names: []string{"exclaim", "trim"}, want: "Hello !"},
+		{name: "unknown transform", names: []string{"trim", "missing"}, wantErr: grab.ErrNotRegistered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := grab.ComposeTransforms(&transforms, tt.names...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, fn("  Hello "))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Package unsafeconv converts between strings and byte slices without copying.
+//
+// The zero-copy conversions break Go's guarantee that strings are immutable, so they are only safe when
+// the rules documented on each function are followed. Prefer the safe, copying conversions unless
+// profiling shows that conversions dominate, such as when parsing multi-gigabyte exports.
+package unsafeconv
+
+import "unsafe"
+
+// UnsafeString returns a string which shares memory with the byte slice, without copying.
+//
+// The byte slice must not be modified for as long as the returned string (or any substring of it) is in use,
+// including strings stored in maps or retained by other data structures. Modifying it changes the string,
+// which can corrupt maps and cause undefined behaviour.
+//
+// Example:
+// line := scanner.Bytes()
+// n, err := strconv.Atoi(UnsafeString(line)) // safe, as the string isn't retained
+func UnsafeString(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// UnsafeBytes returns a byte slice which shares memory with the string, without copying.
+//
+// The returned slice must never be modified, as strings may be stored in read-only memory and
+// modifying them can crash the program. It is only suitable for passing to functions which read bytes.
+//
+// Example:
+// w.Write(UnsafeBytes(header))
+func UnsafeBytes(s string) []byte {
+	if s == "" {
+		return nil
+	}
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// SafeString returns a copy of the byte slice as a string. It is equivalent to string(b),
+// and is provided so call sites can make the safe choice explicit alongside UnsafeString.
+func SafeString(b []byte) string {
+	return string(b)
+}
+
+// SafeBytes returns a copy of the string as a byte slice. It is equivalent to []byte(s),
+// and is provided so call sites can make the safe choice explicit alongside UnsafeBytes.
+func SafeBytes(s string) []byte {
+	return []byte(s)
+}
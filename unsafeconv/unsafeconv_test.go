@@ -0,0 +1,37 @@
+package unsafeconv_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab/unsafeconv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsafeString(t *testing.T) {
+	assert.Equal(t, "", unsafeconv.UnsafeString(nil))
+	assert.Equal(t, "", unsafeconv.UnsafeString([]byte{}))
+
+	b := []byte("hello")
+	s := unsafeconv.UnsafeString(b)
+	assert.Equal(t, "hello", s)
+
+	// the string shares memory with the slice
+	b[0] = 'j'
+	assert.Equal(t, "jello", s)
+}
+
+func TestUnsafeBytes(t *testing.T) {
+	assert.Nil(t, unsafeconv.UnsafeBytes(""))
+	assert.Equal(t, []byte("hello"), unsafeconv.UnsafeBytes("hello"))
+}
+
+func TestSafeConversions(t *testing.T) {
+	b := []byte("hello")
+	s := unsafeconv.SafeString(b)
+	b[0] = 'j'
+	assert.Equal(t, "hello", s)
+
+	copied := unsafeconv.SafeBytes("hello")
+	copied[0] = 'j'
+	assert.Equal(t, []byte("jello"), copied)
+}
@@ -0,0 +1,78 @@
+package grab
+
+// UpsertPlan is the set of batched changes needed to make a persisted collection match an incoming collection.
+type UpsertPlan[T any] struct {
+	// Inserts are incoming items whose key doesn't exist.
+	Inserts [][]T
+	// Updates are incoming items whose key exists, but whose value differs from the existing item.
+	Updates [][]T
+	// Deletes are existing items whose key isn't in the incoming items.
+	Deletes [][]T
+}
+
+// PlanUpserts compares existing and incoming items by key and plans the inserts, updates and deletes
+// needed to make the existing items match the incoming items, split into batches.
+// It is a generic function that works with any item type 'T' and any comparable key type 'K'.
+//
+// Parameters:
+//   - existing: The items currently persisted. Keys are expected to be unique.
+//   - incoming: The desired items. Keys are expected to be unique.
+//   - keyFn: A function returning the key identifying an item.
+//   - eqFn: A function reporting whether an existing and an incoming item with the same key are equal.
+//     Items which are equal are left out of the plan.
+//   - maxBatch: The maximum number of items in each batch. A value of zero or less puts all items of a kind in a single batch.
+//
+// Returns:
+//   - UpsertPlan[T]: The batched changes. Inserts and updates follow the order of 'incoming',
+//     and deletes follow the order of 'existing'.
+//
+// Example:
+// plan := PlanUpserts(stored, fetched, func(u User) string { return u.ID }, func(a, b User) bool { return a == b }, 25)
+//
+//	for _, batch := range plan.Inserts {
+//	    err := db.BatchPut(ctx, batch)
+//	}
+//
+// Note: This function is useful for syncing a listing fetched with AllPages into a database with batch size limits.
+func PlanUpserts[T any, K comparable](existing, incoming []T, keyFn func(T) K, eqFn func(existing, incoming T) bool, maxBatch int) UpsertPlan[T] {
+	existingByKey := make(map[K]T, len(existing))
+	for _, item := range existing {
+		existingByKey[keyFn(item)] = item
+	}
+
+	incomingKeys := make(map[K]struct{}, len(incoming))
+	var inserts, updates, deletes []T
+	for _, item := range incoming {
+		key := keyFn(item)
+		incomingKeys[key] = struct{}{}
+		current, ok := existingByKey[key]
+		if !ok {
+			inserts = append(inserts, item)
+		} else if !eqFn(current, item) {
+			updates = append(updates, item)
+		}
+	}
+
+	for _, item := range existing {
+		if _, ok := incomingKeys[keyFn(item)]; !ok {
+			deletes = append(deletes, item)
+		}
+	}
+
+	return UpsertPlan[T]{
+		Inserts: batch(inserts, maxBatch),
+		Updates: batch(updates, maxBatch),
+		Deletes: batch(deletes, maxBatch),
+	}
+}
+
+// batch splits items into chunks of at most 'size' items, or a single chunk if size is zero or less.
+func batch[T any](items []T, size int) [][]T {
+	if len(items) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		return [][]T{items}
+	}
+	return Chunk(items, size)
+}
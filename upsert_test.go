@@ -0,0 +1,61 @@
+package grab_test
+
+import (
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	ID    string
+	Value int
+}
+
+func TestPlanUpserts(t *testing.T) {
+	keyFn := func(r record) string { return r.ID }
+	eqFn := func(a, b record) bool { return a == b }
+
+	tests := []struct {
+		name     string
+		existing []record
+		incoming []record
+		maxBatch int
+		want     grab.UpsertPlan[record]
+	}{
+		{
+			name: "empty",
+			want: grab.UpsertPlan[record]{},
+		},
+		{
+			name:     "inserts, updates and deletes",
+			existing: []record{{"a", 1}, {"b", 2}, {"c", 3}},
+			incoming: []record{{"b", 20}, {"c", 3}, {"d", 4}},
+			want: grab.UpsertPlan[record]{
+				Inserts: [][]record{{{"d", 4}}},
+				Updates: [][]record{{{"b", 20}}},
+				Deletes: [][]record{{{"a", 1}}},
+			},
+		},
+		{
+			name:     "batched",
+			incoming: []record{{"a", 1}, {"b", 2}, {"c", 3}},
+			maxBatch: 2,
+			want: grab.UpsertPlan[record]{
+				Inserts: [][]record{{{"a", 1}, {"b", 2}}, {{"c", 3}}},
+			},
+		},
+		{
+			name:     "unchanged",
+			existing: []record{{"a", 1}},
+			incoming: []record{{"a", 1}},
+			want:     grab.UpsertPlan[record]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grab.PlanUpserts(tt.existing, tt.incoming, keyFn, eqFn, tt.maxBatch)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package grab
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// SkipChildren can be returned by the visit function passed to Walk to skip the fields,
+// elements or entries of the current value. It is not returned as an error by Walk.
+var SkipChildren = errors.New("skip children")
+
+// Walk traverses a value depth first, calling 'visit' for the value and every struct field, slice or array element
+// and map entry nested inside it, along with a path describing where the value is.
+//
+// Paths are built from struct field names joined with ".", and slice indexes and map keys in brackets,
+// such as "Grants[0].Tags[env]". The root value has an empty path. Pointers and interfaces are followed
+// without adding to the path, and nil pointers and interfaces are visited but not followed.
+//
+// Parameters:
+//   - v: The value to traverse.
+//   - visit: A function called for each value. Returning SkipChildren skips the nested values of the current value,
+//     and returning any other error stops the walk.
+//
+// Returns:
+//   - error: The first error returned by 'visit', other than SkipChildren.
+//
+// Example:
+//
+//	err := Walk(request, func(path string, value reflect.Value) error {
+//	    if value.Kind() == reflect.String && strings.HasSuffix(path, "Password") {
+//	        return fmt.Errorf("%s must not be set", path)
+//	    }
+//	    return nil
+//	})
+//
+// Note: Unexported struct fields are skipped, map entries are visited in order of their formatted keys
+// so walks are deterministic, and pointers which have already been visited are not followed again, so cyclic
+// structures are safe to walk.
+func Walk(v any, visit func(path string, value reflect.Value) error) error {
+	w := walker{visit: visit, seen: make(map[walkedPointer]bool)}
+	return w.walk("", reflect.ValueOf(v))
+}
+
+type walkedPointer struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+type walker struct {
+	visit func(path string, value reflect.Value) error
+	seen  map[walkedPointer]bool
+}
+
+func (w walker) walk(path string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return w.visitValue(path, v)
+		}
+		if v.Kind() == reflect.Pointer {
+			key := walkedPointer{typ: v.Type(), ptr: v.Pointer()}
+			if w.seen[key] {
+				return nil
+			}
+			w.seen[key] = true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	err := w.visit(path, v)
+	if errors.Is(err, SkipChildren) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if err := w.walk(joinPath(path, field.Name), v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(path+"["+strconv.Itoa(i)+"]", v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			if err := w.walk(fmt.Sprintf("%s[%v]", path, key.Interface()), v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// visitValue calls visit for a value which has no children.
+func (w walker) visitValue(path string, v reflect.Value) error {
+	if err := w.visit(path, v); err != nil && !errors.Is(err, SkipChildren) {
+		return err
+	}
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
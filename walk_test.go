@@ -0,0 +1,79 @@
+package grab_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+type walkGrant struct {
+	Role   string
+	Tags   map[string]string
+	Target *walkTarget
+	secret string
+}
+
+type walkTarget struct {
+	Accounts []string
+	Parent   *walkTarget
+}
+
+func TestWalk(t *testing.T) {
+	target := &walkTarget{Accounts: []string{"111", "222"}}
+	target.Parent = target // cycles are not followed
+	grant := walkGrant{
+		Role:   "admin",
+		Tags:   map[string]string{"team": "eng", "env": "prod"},
+		Target: target,
+		secret: "hidden",
+	}
+
+	var paths []string
+	err := grab.Walk(grant, func(path string, value reflect.Value) error {
+		paths = append(paths, fmt.Sprintf("%s=%v", path, value.Kind()))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"=struct",
+		"Role=string",
+		"Tags=map",
+		"Tags[env]=string",
+		"Tags[team]=string",
+		"Target=struct",
+		"Target.Accounts=slice",
+		"Target.Accounts[0]=string",
+		"Target.Accounts[1]=string",
+	}, paths)
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	var paths []string
+	err := grab.Walk(walkGrant{Tags: map[string]string{"team": "eng"}}, func(path string, value reflect.Value) error {
+		paths = append(paths, path)
+		if path == "Tags" {
+			return grab.SkipChildren
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "Role", "Tags", "Target"}, paths)
+}
+
+func TestWalkError(t *testing.T) {
+	errMock := errors.New("mock")
+	var paths []string
+	err := grab.Walk([]int{1, 2, 3}, func(path string, value reflect.Value) error {
+		paths = append(paths, path)
+		if path == "[1]" {
+			return errMock
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errMock)
+	assert.Equal(t, []string{"", "[0]", "[1]"}, paths)
+}
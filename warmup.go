@@ -0,0 +1,99 @@
+package grab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WarmUpLoader is a named initial load run by WarmUp.
+type WarmUpLoader struct {
+	// Name identifies the cache being loaded, such as "users". It is used to report failures.
+	Name string
+	// Load performs the initial load, such as the WaitReady method of a Refresher.
+	Load func(ctx context.Context) error
+}
+
+// WarmUpFailure describes a loader which failed during WarmUp.
+type WarmUpFailure struct {
+	// Name is the name of the loader which failed.
+	Name string
+	// Err is the error returned by the loader.
+	Err error
+}
+
+// WarmUpError is returned by WarmUp when one or more loaders fail.
+type WarmUpError struct {
+	Failures []WarmUpFailure
+}
+
+func (e *WarmUpError) Error() string {
+	msgs := Map(e.Failures, func(f WarmUpFailure) string {
+		return fmt.Sprintf("%s: %s", f.Name, f.Err)
+	})
+	return fmt.Sprintf("warm up failed: %s", strings.Join(msgs, "; "))
+}
+
+// Names returns the names of the loaders which failed, in the order they were passed to WarmUp.
+func (e *WarmUpError) Names() []string {
+	return Map(e.Failures, func(f WarmUpFailure) string { return f.Name })
+}
+
+// Unwrap returns the errors of the failed loaders, so that errors.Is and errors.As can inspect them.
+func (e *WarmUpError) Unwrap() []error {
+	return Map(e.Failures, func(f WarmUpFailure) error { return f.Err })
+}
+
+// WarmUp runs initial loads concurrently, each with its own timeout, and reports which caches failed to load.
+//
+// Parameters:
+//   - ctx: The parent context for the loaders.
+//   - timeout: The maximum time each loader may take. A timeout of zero or less means no timeout is applied.
+//   - loaders: The named loads to run, such as the WaitReady method of each Refresher a service depends on.
+//
+// Returns:
+//   - error: A *WarmUpError naming every loader which failed, in the order they were passed, or nil if all loaders succeeded.
+//
+// Example:
+//
+//	err := WarmUp(ctx, 30*time.Second,
+//	    WarmUpLoader{Name: "users", Load: users.WaitReady},
+//	    WarmUpLoader{Name: "groups", Load: groups.WaitReady},
+//	    WarmUpLoader{Name: "policies", Load: loadPolicies},
+//	)
+//	// err: "warm up failed: groups: context deadline exceeded: last load failed: throttled"
+//
+// Note: This function is useful for service startup, where several caches must be populated before
+// the service starts accepting traffic.
+func WarmUp(ctx context.Context, timeout time.Duration, loaders ...WarmUpLoader) error {
+	errs := make([]error, len(loaders))
+
+	var wg sync.WaitGroup
+	for i, loader := range loaders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loadCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				loadCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			errs[i] = loader.Load(loadCtx)
+		}()
+	}
+	wg.Wait()
+
+	var failures []WarmUpFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, WarmUpFailure{Name: loaders[i].Name, Err: err})
+		}
+	}
+	if len(failures) > 0 {
+		return &WarmUpError{Failures: failures}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package grab_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUp(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	mockErr := errors.New("mock")
+	failing := func(ctx context.Context) error { return mockErr }
+
+	tests := []struct {
+		name         string
+		loaders      []grab.WarmUpLoader
+		wantFailures []string
+		wantErr      string
+	}{
+		{
+			name:    "no loaders",
+			loaders: nil,
+		},
+		{
+			name:    "all succeed",
+			loaders: []grab.WarmUpLoader{{Name: "users", Load: ok}, {Name: "groups", Load: ok}},
+		},
+		{
+			name:         "failures are reported by name",
+			loaders:      []grab.WarmUpLoader{{Name: "users", Load: ok}, {Name: "groups", Load: failing}, {Name: "policies", Load: slow}},
+			wantFailures: []string{"groups", "policies"},
+			wantErr:      "warm up failed: groups: mock; policies: context deadline exceeded",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := grab.WarmUp(context.Background(), 10*time.Millisecond, tt.loaders...)
+			if tt.wantFailures == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var warmUpErr *grab.WarmUpError
+			assert.ErrorAs(t, err, &warmUpErr)
+			assert.Equal(t, tt.wantFailures, warmUpErr.Names())
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, mockErr)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestWarmUpRefreshers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ready := grab.NewRefresher(ctx, time.Minute, func(ctx context.Context) (int, error) { return 1, nil })
+	broken := grab.NewRefresher(ctx, time.Minute, func(ctx context.Context) (int, error) { return 0, errors.New("mock") })
+
+	err := grab.WarmUp(ctx, 20*time.Millisecond,
+		grab.WarmUpLoader{Name: "ready", Load: ready.WaitReady},
+		grab.WarmUpLoader{Name: "broken", Load: broken.WaitReady},
+	)
+	var warmUpErr *grab.WarmUpError
+	assert.ErrorAs(t, err, &warmUpErr)
+	assert.Len(t, warmUpErr.Failures, 1)
+	assert.Equal(t, "broken", warmUpErr.Failures[0].Name)
+	assert.EqualError(t, warmUpErr.Failures[0].Err, "context deadline exceeded: last load failed: mock")
+}
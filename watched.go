@@ -0,0 +1,96 @@
+package grab
+
+import (
+	"context"
+	"sync"
+)
+
+// Watched holds a value and a version number which increases each time the value is set,
+// and allows consumers to be notified of changes.
+// It is safe for concurrent use.
+//
+// Example:
+// config := NewWatched(initialConfig)
+//
+//	go func() {
+//	    for cfg := range config.Watch(ctx) {
+//	        applyConfig(cfg)
+//	    }
+//	}()
+//
+// config.Set(reloadedConfig) // applyConfig is called with reloadedConfig
+//
+// Note: This type is useful for handing updated values, such as reloaded configuration or refreshed
+// listings, from a background process to request handlers.
+type Watched[T any] struct {
+	mu      sync.RWMutex
+	value   T
+	version uint64
+	changed chan struct{}
+}
+
+// NewWatched creates a Watched holding the initial value at version 0.
+func NewWatched[T any](initial T) *Watched[T] {
+	return &Watched[T]{value: initial, changed: make(chan struct{})}
+}
+
+// Get returns the current value and its version.
+func (w *Watched[T]) Get() (T, uint64) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.value, w.version
+}
+
+// Set replaces the value, notifies watchers, and returns the new version.
+func (w *Watched[T]) Set(value T) uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.value = value
+	w.version++
+	close(w.changed)
+	w.changed = make(chan struct{})
+	return w.version
+}
+
+// Changed returns a channel which is closed once the version is greater than 'version'.
+// If the version is already greater, the returned channel is already closed.
+func (w *Watched[T]) Changed(version uint64) <-chan struct{} {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.version > version {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return w.changed
+}
+
+// Watch returns a channel which receives the value each time it is set after Watch is called.
+// If the consumer falls behind, intermediate values are skipped and it receives the latest value.
+// The channel is closed when the context is cancelled.
+func (w *Watched[T]) Watch(ctx context.Context) <-chan T {
+	out := make(chan T)
+	_, version := w.Get()
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-w.Changed(version):
+			case <-ctx.Done():
+				return
+			}
+
+			var value T
+			value, version = w.Get()
+
+			select {
+			case out <- value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,66 @@
+package grab_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatched(t *testing.T) {
+	w := grab.NewWatched("initial")
+	value, version := w.Get()
+	assert.Equal(t, "initial", value)
+	assert.Equal(t, uint64(0), version)
+
+	changed := w.Changed(0)
+	select {
+	case <-changed:
+		t.Fatal("changed before set")
+	default:
+	}
+
+	assert.Equal(t, uint64(1), w.Set("updated"))
+	<-changed
+	<-w.Changed(0)
+
+	value, version = w.Get()
+	assert.Equal(t, "updated", value)
+	assert.Equal(t, uint64(1), version)
+}
+
+func TestWatchedWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := grab.NewWatched(0)
+	values := w.Watch(ctx)
+
+	w.Set(1)
+	assert.Equal(t, 1, receive(t, values))
+
+	w.Set(2)
+	w.Set(3)
+	// the consumer fell behind, so it may observe 2 before 3, but always ends on the latest value
+	got := receive(t, values)
+	if got == 2 {
+		got = receive(t, values)
+	}
+	assert.Equal(t, 3, got)
+
+	cancel()
+	for range values {
+	}
+}
+
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for value")
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,185 @@
+package grab
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueClosed is returned when taking an item from a queue which has been closed and drained.
+var ErrQueueClosed = errors.New("queue is closed")
+
+// WorkItem is an item in a WorkQueue, along with the scheduling information used to order it.
+type WorkItem[T any] struct {
+	// Value is the item of work.
+	Value T
+	// Priority orders items: items with a higher priority are taken first.
+	Priority int
+	// Deadline orders items with the same priority: items with an earlier deadline are taken first,
+	// and items without a deadline are taken after items with one.
+	Deadline time.Time
+}
+
+// WorkQueue is a concurrent priority queue shared by any number of producers and consumers.
+// Whichever consumer is free next takes the most urgent item in the queue, so urgent work is never stuck
+// behind background work queued to a busy consumer. Items with equal priority and deadline are taken in the order they were pushed.
+// It is safe for concurrent use.
+//
+// Example:
+// queue := NewWorkQueue[Task]()
+//
+// queue.Push(WorkItem[Task]{Value: syncTask})
+// queue.Push(WorkItem[Task]{Value: revokeTask, Priority: 10, Deadline: grant.ExpiresAt})
+//
+//	for {
+//	    item, err := queue.Pop(ctx) // revokeTask is taken before syncTask
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(item.Value)
+//	}
+type WorkQueue[T any] struct {
+	mu     sync.Mutex
+	items  *priorityHeap[WorkItem[T]]
+	closed bool
+	// ready has a buffered slot which is filled when an item may be available, waking one consumer.
+	ready chan struct{}
+}
+
+// NewWorkQueue creates an empty WorkQueue.
+func NewWorkQueue[T any]() *WorkQueue[T] {
+	return &WorkQueue[T]{
+		items: newPriorityHeap(func(a, b WorkItem[T]) bool {
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			if a.Deadline.IsZero() != b.Deadline.IsZero() {
+				return !a.Deadline.IsZero()
+			}
+			return a.Deadline.Before(b.Deadline)
+		}),
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// Push adds items to the queue. It returns ErrQueueClosed if the queue has been closed.
+func (q *WorkQueue[T]) Push(items ...WorkItem[T]) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	for _, item := range items {
+		q.items.push(item)
+	}
+	q.wake()
+	return nil
+}
+
+// Pop removes and returns the most urgent item, blocking until one is available.
+// It returns ErrQueueClosed once the queue is closed and empty, or the context error if the context is cancelled first.
+func (q *WorkQueue[T]) Pop(ctx context.Context) (WorkItem[T], error) {
+	for {
+		q.mu.Lock()
+		if q.items.Len() > 0 {
+			item := q.items.pop()
+			if q.items.Len() > 0 || q.closed {
+				// pass the wakeup on, as other consumers may be waiting
+				q.wake()
+			}
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.wake()
+			q.mu.Unlock()
+			return WorkItem[T]{}, ErrQueueClosed
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return WorkItem[T]{}, ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of items in the queue.
+func (q *WorkQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Close stops the queue from accepting new items. Consumers can continue to take the remaining items,
+// after which Pop returns ErrQueueClosed.
+func (q *WorkQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.wake()
+}
+
+// wake fills the ready slot without blocking. It must be called with the lock held.
+func (q *WorkQueue[T]) wake() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// priorityHeap is a binary heap ordered by 'less', which breaks ties in insertion order.
+type priorityHeap[T any] struct {
+	entries []heapEntry[T]
+	less    func(a, b T) bool
+	seq     uint64
+}
+
+type heapEntry[T any] struct {
+	value T
+	seq   uint64
+}
+
+func newPriorityHeap[T any](less func(a, b T) bool) *priorityHeap[T] {
+	return &priorityHeap[T]{less: less}
+}
+
+func (h *priorityHeap[T]) push(v T) {
+	h.seq++
+	heap.Push(h, heapEntry[T]{value: v, seq: h.seq})
+}
+
+func (h *priorityHeap[T]) pop() T {
+	return heap.Pop(h).(heapEntry[T]).value
+}
+
+// peek returns the first item without removing it. The heap must not be empty.
+func (h *priorityHeap[T]) peek() T {
+	return h.entries[0].value
+}
+
+func (h *priorityHeap[T]) Len() int { return len(h.entries) }
+func (h *priorityHeap[T]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+func (h *priorityHeap[T]) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *priorityHeap[T]) Push(x any)    { h.entries = append(h.entries, x.(heapEntry[T])) }
+func (h *priorityHeap[T]) Pop() any {
+	old := h.entries
+	n := len(old)
+	item := old[n-1]
+	var zero heapEntry[T]
+	old[n-1] = zero
+	h.entries = old[:n-1]
+	return item
+}
@@ -0,0 +1,88 @@
+package grab_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/common-fate/grab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkQueueOrder(t *testing.T) {
+	queue := grab.NewWorkQueue[string]()
+	err := queue.Push(
+		grab.WorkItem[string]{Value: "background-1"},
+		grab.WorkItem[string]{Value: "urgent-later", Priority: 10, Deadline: epoch.Add(time.Hour)},
+		grab.WorkItem[string]{Value: "background-2"},
+		grab.WorkItem[string]{Value: "urgent-sooner", Priority: 10, Deadline: epoch.Add(time.Minute)},
+		grab.WorkItem[string]{Value: "urgent-no-deadline", Priority: 10},
+		grab.WorkItem[string]{Value: "background-deadline", Deadline: epoch},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, queue.Len())
+	queue.Close()
+
+	var got []string
+	for {
+		item, err := queue.Pop(context.Background())
+		if err != nil {
+			assert.ErrorIs(t, err, grab.ErrQueueClosed)
+			break
+		}
+		got = append(got, item.Value)
+	}
+	assert.Equal(t, []string{"urgent-sooner", "urgent-later", "urgent-no-deadline", "background-deadline", "background-1", "background-2"}, got)
+	assert.ErrorIs(t, queue.Push(grab.WorkItem[string]{}), grab.ErrQueueClosed)
+}
+
+func TestWorkQueueConsumers(t *testing.T) {
+	queue := grab.NewWorkQueue[int]()
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		got []int
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				item, err := queue.Pop(context.Background())
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				got = append(got, item.Value)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, queue.Push(grab.WorkItem[int]{Value: i}))
+	}
+	queue.Close()
+	wg.Wait()
+
+	assert.Len(t, got, 100)
+	assert.ElementsMatch(t, seqInts(100), got)
+}
+
+func TestWorkQueuePopCancelled(t *testing.T) {
+	queue := grab.NewWorkQueue[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := queue.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func seqInts(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}